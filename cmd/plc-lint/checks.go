@@ -0,0 +1,27 @@
+package main
+
+// The check packages register themselves with the registry when imported
+import (
+	_ "internal/checks/PLC01-component"
+	_ "internal/checks/PLC02-repository"
+	_ "internal/checks/PLC03-commits"
+	_ "internal/checks/PLC04-folders"
+	_ "internal/checks/PLC05-files"
+	_ "internal/checks/PLC06-gitignore-file"
+	_ "internal/checks/PLC07-gitlab-ci.yml-file"
+	_ "internal/checks/PLC08-mdlrc-file"
+	_ "internal/checks/PLC09-yamllint-file"
+	_ "internal/checks/PLC10-action.yml-file"
+	_ "internal/checks/PLC11-Dockerfile"
+	_ "internal/checks/PLC12-LICENSE-file"
+	_ "internal/checks/PLC13-README.md-file"
+	_ "internal/checks/PLC14-renovate.json-file"
+	_ "internal/checks/PLC15-app-folder"
+	_ "internal/checks/PLC16-github-folder"
+	_ "internal/checks/PLC17-FUNDING.yml-file"
+	_ "internal/checks/PLC18-github-workflows-folder"
+	_ "internal/checks/PLC19-release.yml-file"
+	_ "internal/checks/PLC20-examples-folder"
+	_ "internal/checks/PLC21-yaml-files"
+	_ "internal/checks/PLC22-plc-lint-ignore-file"
+)
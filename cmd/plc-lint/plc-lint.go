@@ -1,41 +1,50 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"internal/baseline"
 	"internal/check"
+	"internal/config"
 	"internal/repositorycontents"
+	"io"
 	"regexp"
+	"slices"
 	"sort"
 
-	plc1 "internal/checks/PLC01-component"
-	plc2 "internal/checks/PLC02-repository"
-	// plc3 "internal/checks/PLC03-commits"
-	plc4 "internal/checks/PLC04-folders"
-	plc5 "internal/checks/PLC05-files"
-	// plc6 "internal/checks/PLC6-gitignore-file
-	// plc7 "internal/checks/PLC7-gitlab-ci.yml-file"
-	plc8 "internal/checks/PLC08-mdlrc-file"
-	plc9 "internal/checks/PLC09-yamllint-file"
-	// plc10 "internal/checks/PLC10-action.yml-file"
-	// plc11 "internal/checks/PLC11-Dockerfile"
-	plc12 "internal/checks/PLC12-LICENSE-file"
-	plc13 "internal/checks/PLC13-README.md-file"
-	plc14 "internal/checks/PLC14-renovate.json-file"
-	plc15 "internal/checks/PLC15-app-folder"
-	plc16 "internal/checks/PLC16-github-folder"
-	plc17 "internal/checks/PLC17-FUNDING.yml-file"
-	plc18 "internal/checks/PLC18-github-workflows-folder"
-	plc19 "internal/checks/PLC19-release.yml-file"
-	// plc20 "internal/checks/PLC20-examples-folder"
 	"internal/directorylist"
 	"internal/exitcodes"
+	"internal/fix"
+	"internal/httpclient"
 	"internal/message"
+	"internal/registry"
+	"internal/repofs"
+	"internal/report"
 	repo "internal/repositorycontents"
+	"internal/skeleton"
+	"internal/suppression"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
+// patternList collects the values of an option that can be given more than once
+type patternList []string
+
+func (p *patternList) String() string {
+	return strings.Join(*p, ", ")
+}
+
+func (p *patternList) Set(value string) error {
+	*p = append(*p, value)
+
+	return nil
+}
+
 type CommandError struct {
 	code    int
 	message string
@@ -51,8 +60,38 @@ func CreateCommandError(
 	}
 }
 
-func getFileList(projectPath string) map[string]string {
-	files, fileListError := loadFiles(projectPath)
+// getFileList reads the files of the component from disk, or, when a ref is
+// given or only tracked files are wanted, from its git repository. On disk,
+// the files that git ignores and those matching the exclude patterns are left
+// out.
+func getFileList(projectPath string, ref string, trackedOnly bool, exclude []string) repofs.RepoFS {
+	var (
+		err   error
+		files repofs.RepoFS
+	)
+
+	fileListError := CreateCommandError(exitcodes.Ok, "")
+
+	if ref != "" {
+		if files, err = repo.GetRefContent(projectPath, ref); err != nil {
+			fileListError = CreateCommandError(
+				exitcodes.CouldNotRead,
+				fmt.Sprintf("could not read files at '%s' from '%s': %v", ref, projectPath, err))
+		}
+	} else if trackedOnly {
+		if files, err = repo.GetIndexContent(projectPath); err != nil {
+			fileListError = CreateCommandError(
+				exitcodes.CouldNotRead,
+				fmt.Sprintf("could not read tracked files from '%s': %v", projectPath, err))
+		}
+	} else {
+		if files, err = repofs.CreateFromDirectory(projectPath, directorylist.Options{Exclude: exclude}); err != nil {
+			// What could be listed is still checked, the checks report what is missing
+			_, _ = fmt.Fprintf(os.Stderr, "not all files could be read from '%s':\n%v\n", projectPath, err)
+		}
+
+		printSymlinks(files, os.Stderr)
+	}
 
 	if fileListError.code != exitcodes.Ok {
 		_, _ = fmt.Fprintf(os.Stderr, "%v\n", fileListError.message)
@@ -62,10 +101,216 @@ func getFileList(projectPath string) map[string]string {
 	return files
 }
 
+// printSymlinks lists the symbolic links in the given files, as they are not
+// followed, so what they point to is not checked.
+func printSymlinks(files repofs.RepoFS, output io.Writer) {
+	for _, name := range files.List() {
+		if info, ok := files.Stat(name); ok && info.IsSymlink {
+			_, _ = fmt.Fprintf(output, "'%s' is a symbolic link, which is not followed\n", name)
+		}
+	}
+}
+
+// applyBaseline leaves out the failures that are recorded for the component
+// in the given baseline, and reports the recorded failures that were fixed.
+func applyBaseline(checks []message.Message, baselinePath string, component string) []message.Message {
+	recorded, err := baseline.Read(baselinePath)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(exitcodes.CouldNotReadFile)
+	}
+
+	return baseline.Apply(checks, recorded[component])
+}
+
+// applySuppressions marks the failures listed in the `.plc-lint-ignore` file
+// as suppressed. Suppressions that do not match any failure are reported as
+// PLC22003, which can only be done once all checks have run. The results of
+// PLC22 itself are never suppressed, and suppressions of codes that no check
+// reports are left to PLC22001.
+func applySuppressions(checks []message.Message, files repofs.RepoFS, configuration config.Config, now time.Time) []message.Message {
+	const unusedCode = "PLC22003"
+
+	content, err := files.ReadFile(suppression.FileName)
+
+	if err != nil {
+		if configuration.IsEnabled(unusedCode) {
+			checks = append(checks, message.CreateMessage(check.Skip, unusedCode, getDescription(unusedCode)))
+		}
+
+		return checks
+	}
+
+	suppressions, _ := suppression.Parse(content)
+	suppressions = slices.DeleteFunc(suppressions, func(entry suppression.Suppression) bool {
+		return !registry.HasCode(entry.Code)
+	})
+	checks, unused := suppression.Apply(checks, suppressions, now)
+
+	if configuration.IsEnabled(unusedCode) {
+		if len(unused) == 0 {
+			checks = append(checks, message.CreateMessage(check.Pass, unusedCode, getDescription(unusedCode)))
+		}
+
+		for _, entry := range unused {
+			checks = append(checks, message.CreateLocatedMessage(
+				check.Fail,
+				unusedCode,
+				"",
+				entry.Line,
+				0,
+				fmt.Sprintf("The suppression of %s does not match any failed check", entry.Code),
+			))
+		}
+	}
+
+	return checks
+}
+
+func createContext(
+	projectPath string,
+	files repofs.RepoFS,
+	skeletonContent repofs.RepoFS,
+	repoLogs []repo.LogEntry,
+	mainLogs []repo.LogEntry,
+	repoDetails repositorycontents.Details,
+	httpClient *httpclient.Client,
+	requireSignedCommits bool,
+) registry.Context {
+	return registry.Context{
+		ComponentName: filepath.Base(projectPath),
+		// Replaced for each check when the checks are run
+		Context:              context.Background(),
+		Files:                files,
+		HTTPClient:           httpClient,
+		Logs:                 repoLogs,
+		MainLogs:             mainLogs,
+		ProjectPath:          projectPath,
+		RepoDetails:          repoDetails,
+		RequireSignedCommits: requireSignedCommits,
+		Skeleton:             skeletonContent,
+	}
+}
+
+// getConfig loads the configuration files for the given project, with the
+// options given on the command line on top.
+func getConfig(projectPath string) (config.Config, []string) {
+	configuration, loaded, err := config.Load(config.Paths(projectPath))
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(exitcodes.ValidationFailed)
+	}
+
+	flagConfig := config.Config{}
+
+	flag.Visit(func(option *flag.Flag) {
+		switch option.Name {
+		case "fail-on":
+			flagConfig.FailOn = option.Value.String()
+		case "format":
+			flagConfig.Format = option.Value.String()
+		case "cache-ttl":
+			flagConfig.CacheTTL = option.Value.String()
+		case "skeleton-ref":
+			flagConfig.SkeletonRef = option.Value.String()
+		case "check-timeout":
+			flagConfig.CheckTimeout = option.Value.String()
+		case "timeout":
+			flagConfig.Timeout = option.Value.String()
+		case "http-timeout":
+			flagConfig.HTTPTimeout = option.Value.String()
+		case "url-cache-ttl":
+			flagConfig.URLCacheTTL = option.Value.String()
+		case "proxy":
+			flagConfig.Proxy = option.Value.String()
+		case "exclude":
+			flagConfig.Exclude = *option.Value.(*patternList)
+		}
+	})
+
+	if flag.NArg() > 1 {
+		flagConfig.Skeleton = flag.Arg(1)
+	}
+
+	if err = flagConfig.Validate(); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(exitcodes.InvalidParameter)
+	}
+
+	configuration = configuration.Merge(flagConfig)
+
+	if err = validateCodes(configuration); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(exitcodes.ValidationFailed)
+	}
+
+	return configuration, loaded
+}
+
+// getChanges asks the checks that can fix their own failures for the files
+// that should be written.
+func getChanges(checkContext registry.Context, checks []message.Message) []fix.Change {
+	var changes []fix.Change
+
+	for _, registered := range registry.Checks() {
+		if fixer, ok := registered.(registry.Fixer); ok {
+			var messages []message.Message
+
+			for _, checkMessage := range checks {
+				if _, ok := registered.Codes()[checkMessage.Code]; ok {
+					messages = append(messages, checkMessage)
+				}
+			}
+
+			changes = append(changes, fixer.Fix(checkContext, messages)...)
+		}
+	}
+
+	return fix.Merge(changes)
+}
+
+func getDescription(code string) string {
+	var description string
+
+	for _, registered := range registry.Checks() {
+		if codeDescription, ok := registered.Codes()[code]; ok {
+			description = codeDescription
+		}
+	}
+
+	return description
+}
+
+// getExitCode derives the exit code from the check results. Only results at,
+// or above, the given threshold make the command fail. The thresholds are
+// ordered from strict to lenient: "fail" also fails on incomplete checks and
+// errors, "incomplete" also fails on errors, "error" only fails on errors.
+func getExitCode(checks []message.Message, failOn string) int {
+	exitCode := exitcodes.Ok
+	threshold := slices.Index(config.FailOnLevels, failOn)
+
+	for _, checkMessage := range checks {
+		switch {
+		case checkMessage.Status == check.Error && threshold <= 2:
+			exitCode = exitcodes.ChecksIncomplete
+		case checkMessage.Status == check.Incomplete && threshold <= 1:
+			exitCode = exitcodes.ChecksIncomplete
+		case checkMessage.Status == check.Fail && threshold == 0 && exitCode == exitcodes.Ok:
+			exitCode = exitcodes.ChecksFailed
+		}
+	}
+
+	return exitCode
+}
+
 func getMarkerForStatus(messageStatus check.Status, messageMarker message.Marker) string {
 	var marker string
 
 	switch messageStatus {
+	case check.Error:
+		marker = messageMarker.Error
 	case check.Pass:
 		marker = messageMarker.Pass
 	case check.Fail:
@@ -74,6 +319,8 @@ func getMarkerForStatus(messageStatus check.Status, messageMarker message.Marker
 		marker = messageMarker.Skip
 	case check.Incomplete:
 		marker = messageMarker.Incomplete
+	case check.Suppressed:
+		marker = messageMarker.Suppressed
 	default:
 		errorMessage := fmt.Sprintf("Unknown or unsupported CheckStatus '%v'", messageStatus)
 		panic(errorMessage)
@@ -82,13 +329,14 @@ func getMarkerForStatus(messageStatus check.Status, messageMarker message.Marker
 	return marker
 }
 
-func getMessageMarkers() message.Marker {
-	// @TODO: Markers should be overridable from a configuration file
+func getMessageMarkers(markers config.Markers) message.Marker {
 	messageMarker := message.Marker{
-		Pass:       "✅",
-		Fail:       "❌",
-		Skip:       "⏭ ",
-		Incomplete: "⚠️",
+		Error:      markers.Error,
+		Pass:       markers.Pass,
+		Fail:       markers.Fail,
+		Skip:       markers.Skip,
+		Incomplete: markers.Incomplete,
+		Suppressed: markers.Suppressed,
 	}
 
 	return messageMarker
@@ -133,8 +381,8 @@ func getPath(projectPath string) (string, CommandError) {
 func getProjectPath() string {
 	projectPath := "."
 
-	if len(os.Args) > 1 {
-		projectPath = os.Args[1]
+	if flag.NArg() > 0 {
+		projectPath = flag.Arg(0)
 	}
 
 	projectPath, pathError := getPath(projectPath)
@@ -147,43 +395,47 @@ func getProjectPath() string {
 	return projectPath
 }
 
-func loadFiles(path string) (map[string]string, CommandError) {
-	var fileMap = make(map[string]string)
-
-	commandError := CreateCommandError(exitcodes.Ok, "")
-
-	files, err := directorylist.ListContent(path, "")
-
-	if err != nil {
-		commandError = CreateCommandError(
-			exitcodes.CouldNotRead,
-			fmt.Sprintf("could not read files from '%s': %v", path, err))
-	} else {
-		for _, file := range files {
-			if strings.HasSuffix(file, "/") {
-				fileMap[file] = "__DIR__"
-			} else {
-				contentPath := filepath.Join(path, file)
-				contents, err := os.ReadFile(contentPath)
+func listFamilies() []report.Family {
+	var families []report.Family
 
-				if err != nil {
-					commandError = CreateCommandError(
-						exitcodes.CouldNotRead,
-						fmt.Sprintf("could not read file '%s': %v", file, err))
+	for _, registered := range registry.Checks() {
+		families = append(families, report.Family{
+			Codes: registered.Codes(),
+			ID:    registered.ID(),
+			Path:  registered.Path(),
+			Title: registered.Title(),
+		})
+	}
 
-					break
-				}
+	return families
+}
 
-				fileMap[file] = string(contents)
-			}
-		}
+// loadRepoBranchLogs returns the history of the given branch. When a ref is
+// checked, that is what would become the branch, so its history is used.
+func loadRepoBranchLogs(path string, branch string, ref string) []repo.LogEntry {
+	if ref != "" {
+		return loadRepoLogs(path, ref)
 	}
 
-	return fileMap, commandError
+	// A missing branch is not an error, the checks that need it will be skipped
+	repoLogs, _ := repo.GetBranchLogs(path, branch)
+
+	return repoLogs
 }
 
-func loadRepoLogs(path string) []repo.LogEntry {
-	repoLogs, err := repo.GetLogs(path)
+// loadRepoLogs returns the history of the component, from the given ref or,
+// without one, from HEAD.
+func loadRepoLogs(path string, ref string) []repo.LogEntry {
+	var (
+		err      error
+		repoLogs []repo.LogEntry
+	)
+
+	if ref != "" {
+		repoLogs, err = repo.GetRefLogs(path, ref)
+	} else {
+		repoLogs, err = repo.GetLogs(path)
+	}
 
 	if err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "%v\n", err)
@@ -204,66 +456,188 @@ func loadRepoDetails(path string) repositorycontents.Details {
 	return repoDetails
 }
 
-func loadSkeletonFileList() map[string]string {
+// resolveRef returns the commit the given ref points to, so all that is read
+// from the repository is read at the same commit. An empty ref stays empty, as
+// the working tree is checked then.
+func resolveRef(path string, ref string) string {
+	if ref == "" {
+		return ref
+	}
+
+	hash, err := repo.ResolveRef(path, ref)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "could not find '%s' in '%s': %v\n", ref, path, err)
+		os.Exit(exitcodes.CouldNotRead)
+	}
+
+	return hash
+}
+
+// createHTTPClient creates the client that makes the HTTP requests of all
+// checks. URL results are cached for the URL cache TTL, which is separate from
+// that of the skeleton repository.
+func createHTTPClient(configuration config.Config) *httpclient.Client {
+	// The durations have already been validated with the rest of the configuration
+	timeout, _ := time.ParseDuration(configuration.HTTPTimeout)
+	ttl, _ := time.ParseDuration(configuration.URLCacheTTL)
+
+	options := httpclient.Options{CacheTTL: ttl, Proxy: configuration.Proxy, Retries: 2, Timeout: timeout}
+
+	// Without a TTL (or a place to keep it) nothing is cached between runs
+	if cacheDir, err := httpclient.DefaultCacheDir(); err == nil && ttl > 0 {
+		options.CacheDir = cacheDir
+	}
+
+	client, err := httpclient.CreateClient(options)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(exitcodes.InvalidParameter)
+	}
+
+	return client
+}
+
+// loadSkeleton loads the skeleton set in the configuration. A repository URL
+// is loaded through the cache, a path can point to a git repository (which is
+// read at the configured ref), an archive or a directory.
+func loadSkeleton(configuration config.Config, offline bool) skeleton.Skeleton {
 	var (
-		fileListError   CommandError
-		repoError       CommandError
-		skeletonContent map[string]string
+		err    error
+		result skeleton.Skeleton
+		source skeleton.Source
 	)
 
-	if len(os.Args) > 2 {
-		skeletonPath := os.Args[2]
-		skeletonPath, pathError := getPath(skeletonPath)
+	exitCode := exitcodes.CouldNotRead
 
-		if pathError.code != exitcodes.Ok {
-			_, _ = fmt.Fprintf(os.Stderr, "%v\n", pathError.message)
-			os.Exit(pathError.code)
-		}
+	if config.IsRemote(configuration.Skeleton) {
+		var cacheDir string
 
-		skeletonContent, fileListError = loadFiles(skeletonPath)
+		cacheDir, err = skeleton.DefaultDir()
+		exitCode = exitcodes.CouldNotDownload
 
-		if fileListError.code != exitcodes.Ok {
-			_, _ = fmt.Fprintf(os.Stderr, "%v\n", fileListError.message)
-			os.Exit(fileListError.code)
+		// The TTL has already been validated with the rest of the configuration
+		ttl, _ := time.ParseDuration(configuration.CacheTTL)
+
+		source = skeleton.Remote{
+			Cache: skeleton.Cache{Dir: cacheDir, Offline: offline, TTL: ttl},
+			URL:   configuration.Skeleton,
 		}
 	} else {
-		skeletonContent, repoError = loadSkeletonRepoContent("https://gitlab.com/pipeline-components/org/skeleton.git")
+		var skeletonPath string
+
+		skeletonPath, err = filepath.Abs(configuration.Skeleton)
 
-		if repoError.code != exitcodes.Ok {
-			_, _ = fmt.Fprintf(os.Stderr, "%v\n", repoError.message)
-			os.Exit(repoError.code)
+		if err == nil {
+			if _, err = os.Stat(skeletonPath); errors.Is(err, os.ErrNotExist) {
+				err = fmt.Errorf("provided skeleton '%s' does not exist", skeletonPath)
+				exitCode = exitcodes.CouldNotFind
+			}
 		}
+
+		source = skeleton.Local(skeletonPath)
+	}
+
+	if err == nil {
+		result, err = source.Load(configuration.SkeletonRef)
 	}
 
-	return skeletonContent
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(exitCode)
+	}
+
+	if result.Warning != "" {
+		_, _ = fmt.Fprintf(os.Stderr, "%s\n", result.Warning)
+	}
+
+	return result
 }
 
-func loadSkeletonRepoContent(repoPath string) (map[string]string, CommandError) {
-	commandError := CreateCommandError(exitcodes.Ok, "")
+// printErrorSummary lists the checks that could not be run, and why, so they
+// do not go unnoticed between the other results.
+func printErrorSummary(checks []message.Message, output io.Writer) {
+	var errorMessages []string
+
+	for _, checkMessage := range checks {
+		if checkMessage.Status == check.Error {
+			errorMessages = append(errorMessages, fmt.Sprintf("  %s %s\n", checkMessage.Code, checkMessage.Reason))
+		}
+	}
+
+	if len(errorMessages) > 0 {
+		sortMessages(errorMessages)
+
+		_, _ = fmt.Fprintf(output, "\n%d check(s) could not be run:\n%s", len(errorMessages), strings.Join(errorMessages, ""))
+	}
+}
+
+// printChanges lists the changes that were made by --fix
+func printChanges(changes []fix.Change, output io.Writer) {
+	if len(changes) > 0 {
+		_, _ = fmt.Fprintf(output, "\nChanged %d file(s):\n", len(changes))
+
+		for _, change := range changes {
+			_, _ = fmt.Fprintf(output, "  %s %s\n", change.Code, change.Description)
+		}
+	}
+}
+
+// printSkeleton shows which skeleton the component was checked against, with
+// the commit that was used, so a run can be reproduced.
+func printSkeleton(skeletonRepository skeleton.Skeleton, output io.Writer) {
+	_, _ = fmt.Fprintf(output, "\nSkeleton: %s\n", skeletonRepository)
+}
+
+// printConfig shows the configuration that is used, with the files it was
+// loaded from.
+func printConfig(configuration config.Config, loaded []string) {
+	output, err := configuration.Marshal()
+
+	if err == nil {
+		for _, path := range loaded {
+			_, _ = fmt.Fprintf(os.Stdout, "# Loaded from %s\n", path)
+		}
 
-	files, err := repo.GetContent(repoPath)
+		_, err = fmt.Fprint(os.Stdout, string(output))
+	}
 
 	if err != nil {
-		commandError = CreateCommandError(
-			exitcodes.UnknownErrorOccurred,
-			fmt.Sprintf("could not get content from '%s': %v", repoPath, err),
-		)
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitcodes.CouldNotUpdate)
 	}
+}
 
-	return files, commandError
+// indentDiff indents the lines of the given diff, so it stands apart from the
+// messages around it
+func indentDiff(diff string) string {
+	lines := strings.SplitAfter(strings.TrimSuffix(diff, "\n"), "\n")
+
+	return "    " + strings.Join(lines, "    ") + "\n"
 }
 
-func printMessages(checks []message.Message) {
+func printMessages(checks []message.Message, markers config.Markers, verbose bool) {
 	checkMessages := []string{}
 
-	messageMarkers := getMessageMarkers()
+	messageMarkers := getMessageMarkers(markers)
 
 	for _, checkMessage := range checks {
 		statusMarker := getMarkerForStatus(checkMessage.Status, messageMarkers)
+		text := checkMessage.Text()
+
+		if checkMessage.Reason != "" {
+			text = fmt.Sprintf("%s (%s)", text, checkMessage.Reason)
+		}
+
 		checkMessages = append(
 			checkMessages,
-			fmt.Sprintf("%s %s %s\n", checkMessage.Code, statusMarker, checkMessage.Message),
+			fmt.Sprintf("%s %s %s\n", checkMessage.Code, statusMarker, text),
 		)
+
+		if verbose && checkMessage.Diff != "" {
+			checkMessages[len(checkMessages)-1] += indentDiff(checkMessage.Diff)
+		}
 	}
 
 	sortMessages(checkMessages)
@@ -276,32 +650,96 @@ func printMessages(checks []message.Message) {
 	}
 }
 
-func runChecks(
-	projectPath string,
-	files map[string]string,
-	skeletonContent map[string]string,
-	repoLogs []repo.LogEntry,
-	repoDetails repositorycontents.Details,
-) []message.Message {
-	var checks []message.Message
-
-	componentName := filepath.Base(projectPath)
-
-	checks = append(checks, plc1.PLC1(projectPath, files, repoLogs)...)
-	checks = append(checks, plc2.PLC2(repoDetails)...)
-	checks = append(checks, plc4.PLC4(files)...)
-	checks = append(checks, plc4.PLC4(files)...)
-	checks = append(checks, plc5.PLC5(files)...)
-	checks = append(checks, plc8.PLC8(files, skeletonContent)...)
-	checks = append(checks, plc9.PLC9(files, skeletonContent)...)
-	checks = append(checks, plc12.PLC12(files, skeletonContent, repoLogs)...)
-	checks = append(checks, plc13.PLC13(componentName, files, skeletonContent)...)
-	checks = append(checks, plc14.PLC14(files, skeletonContent)...)
-	checks = append(checks, plc15.PLC15(files)...)
-	checks = append(checks, plc16.PLC16(files)...)
-	checks = append(checks, plc17.PLC17(files, skeletonContent)...)
-	checks = append(checks, plc18.PLC18(files)...)
-	checks = append(checks, plc19.PLC19(files, skeletonContent)...)
+// printPatch shows the given changes as a patch, without making them
+func printPatch(projectPath string, changes []fix.Change) {
+	patch, err := fix.Patch(projectPath, changes)
+
+	if err == nil {
+		_, err = fmt.Fprint(os.Stdout, patch)
+	}
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitcodes.CouldNotUpdate)
+	}
+}
+
+func printReport(checks []message.Message, format string, markers config.Markers, verbose bool, skeletonRepository skeleton.Skeleton) {
+	var (
+		err    error
+		output []byte
+	)
+
+	reportSkeleton := report.Skeleton{Hash: skeletonRepository.Hash, Ref: skeletonRepository.Ref, Source: skeletonRepository.Source}
+
+	switch format {
+	case "gitlab-codequality":
+		output, err = report.GitLabCodeQuality(checks, listFamilies())
+	case "json":
+		output, err = report.JSON(checks, listFamilies(), reportSkeleton)
+	case "junit":
+		output, err = report.JUnit(checks, listFamilies())
+	case "sarif":
+		output, err = report.SARIF(checks, listFamilies(), reportSkeleton)
+	default:
+		printMessages(checks, markers, verbose)
+		printErrorSummary(checks, os.Stdout)
+		return
+	}
+
+	if err == nil {
+		_, err = fmt.Fprintln(os.Stdout, string(output))
+	}
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitcodes.CouldNotUpdate)
+	}
+
+	// Keep machine-readable output on stdout free from anything else
+	printErrorSummary(checks, os.Stderr)
+}
+
+// runChecks runs the enabled checks concurrently, within the timeouts from the
+// configuration. Interrupting the run reports the checks that have not
+// finished as incomplete, rather than losing the results of the others.
+func runChecks(checkContext registry.Context, configuration config.Config) []message.Message {
+	var (
+		checks  []message.Message
+		enabled []registry.Check
+	)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// The durations have been validated along with the rest of the configuration
+	if timeout, _ := time.ParseDuration(configuration.Timeout); timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	checkTimeout, _ := time.ParseDuration(configuration.CheckTimeout)
+
+	for _, registered := range registry.Checks() {
+		isEnabled := false
+
+		for code := range registered.Codes() {
+			isEnabled = isEnabled || configuration.IsEnabled(code)
+		}
+
+		// Checks with all of their codes disabled are not run at all
+		if isEnabled {
+			enabled = append(enabled, registered)
+		}
+	}
+
+	for _, checkMessage := range registry.Run(ctx, enabled, checkContext, checkTimeout) {
+		if configuration.IsEnabled(checkMessage.Code) {
+			checks = append(checks, checkMessage)
+		}
+	}
 
 	return checks
 }
@@ -324,14 +762,141 @@ func sortMessages(checkMessages []string) []string {
 	return checkMessages
 }
 
+// validateCodes makes sure the codes that are enabled or disabled in the
+// configuration belong to a registered check.
+func validateCodes(configuration config.Config) error {
+	var problems []error
+
+	known := map[string]bool{}
+
+	for _, registered := range registry.Checks() {
+		known[registered.ID()] = true
+
+		for code := range registered.Codes() {
+			known[code] = true
+		}
+	}
+
+	for _, code := range append(slices.Clone(configuration.Disable), configuration.Enable...) {
+		if !known[code] {
+			problems = append(problems, fmt.Errorf("unknown PLC code or family '%s' in configuration", code))
+		}
+	}
+
+	return errors.Join(problems...)
+}
+
 func main() {
+	arguments := os.Args[1:]
+	showConfig := len(arguments) > 0 && arguments[0] == "config"
+
+	if showConfig {
+		arguments = arguments[1:]
+	}
+
+	// The defaults are only shown in the help, values that are not set on the
+	// command line are taken from the configuration
+	flag.String("fail-on", "fail", "The lowest result that makes the command fail: "+strings.Join(config.FailOnLevels, ", "))
+	flag.String("format", "text", "The output format: "+strings.Join(config.Formats, ", "))
+	flag.String("cache-ttl", "24h", "How long a cached skeleton repository is used, before it is fetched again")
+	flag.String("skeleton-ref", "", "The branch, tag or commit of the skeleton repository to use")
+	flag.String("timeout", "5m", "How long all checks together may run, before the unfinished ones are reported as incomplete")
+	flag.String("check-timeout", "1m", "How long a single check may run, before it is reported as incomplete")
+	flag.String("http-timeout", "10s", "How long a single HTTP request made by a check may take")
+	flag.String("url-cache-ttl", "24h", "How long the result of a URL that resolves is used, before the URL is checked again")
+	flag.String("proxy", "", "The URL of the proxy to send HTTP requests through, instead of the one from HTTP_PROXY or HTTPS_PROXY")
+	flag.Var(&patternList{}, "exclude", "Leave paths matching the given pattern, in .gitignore syntax, out of the checks (can be given more than once)")
+
+	baselineFlag := flag.String("baseline", "", "Only report failures that are not recorded in the given baseline file")
+	baselineWriteFlag := flag.String("baseline-write", "", "Record the current failures in the given baseline file")
+	dryRunFlag := flag.Bool("dry-run", false, "Show the changes --fix would make as a patch, without making them")
+	refFlag := flag.String("ref", "", "Check the component at the given branch, tag or commit, rather than its working tree")
+	trackedOnlyFlag := flag.Bool("tracked-only", false, "Check the files in the git index of the component, leaving out untracked files")
+	offlineFlag := flag.Bool("offline", false, "Use the cached skeleton repository, without fetching it")
+	fixFlag := flag.Bool("fix", false, "Fix failures by writing the files from the skeleton repository, and creating missing folders")
+	verboseFlag := flag.Bool("verbose", false, "Show how content differs from the skeleton repository, as a unified diff")
+
+	flag.Usage = func() {
+		name := filepath.Base(os.Args[0])
+
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [options] <path-to-component> [<path-to-skeleton>]\n", name)
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "       %s config [options] <path-to-component> [<path-to-skeleton>]\n", name)
+		flag.PrintDefaults()
+	}
+
+	_ = flag.CommandLine.Parse(arguments)
+
+	if *baselineFlag != "" && *baselineWriteFlag != "" {
+		_, _ = fmt.Fprintln(os.Stderr, "the --baseline and --baseline-write options can not be used together")
+		os.Exit(exitcodes.InvalidParameter)
+	}
+
+	if *refFlag != "" && *trackedOnlyFlag {
+		_, _ = fmt.Fprintln(os.Stderr, "the --ref and --tracked-only options can not be used together")
+		os.Exit(exitcodes.InvalidParameter)
+	}
+
+	// Fixes are written to the working tree, which is not what is checked
+	if (*refFlag != "" || *trackedOnlyFlag) && (*fixFlag || *dryRunFlag) {
+		_, _ = fmt.Fprintln(os.Stderr, "the --fix and --dry-run options can not be used with --ref or --tracked-only")
+		os.Exit(exitcodes.InvalidParameter)
+	}
+
 	projectPath := getProjectPath()
+	configuration, loaded := getConfig(projectPath)
 
-	files := getFileList(projectPath)
-	skeletonContent := loadSkeletonFileList()
-	repoLogs := loadRepoLogs(projectPath)
+	if showConfig {
+		printConfig(configuration, loaded)
+		return
+	}
+
+	ref := resolveRef(projectPath, *refFlag)
+	files := getFileList(projectPath, ref, *trackedOnlyFlag, configuration.Exclude)
+	skeletonRepository := loadSkeleton(configuration, *offlineFlag)
+	repoLogs := loadRepoLogs(projectPath, ref)
+	mainLogs := loadRepoBranchLogs(projectPath, "main", ref)
 	repoDetails := loadRepoDetails(projectPath)
-	checks := runChecks(projectPath, files, skeletonContent, repoLogs, repoDetails)
+	httpClient := createHTTPClient(configuration)
+	checkContext := createContext(projectPath, files, skeletonRepository.Files, repoLogs, mainLogs, repoDetails, httpClient, configuration.SignedCommitsRequired())
+	checks := applySuppressions(runChecks(checkContext, configuration), files, configuration, time.Now())
+
+	var changes []fix.Change
+
+	if *dryRunFlag {
+		printPatch(projectPath, getChanges(checkContext, checks))
+		return
+	} else if *fixFlag {
+		changes = getChanges(checkContext, checks)
+
+		if err := fix.Apply(projectPath, changes); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(exitcodes.CouldNotUpdateFile)
+		}
+
+		// The report shows the state after the changes have been made
+		files = getFileList(projectPath, ref, *trackedOnlyFlag, configuration.Exclude)
+		checkContext = createContext(projectPath, files, skeletonRepository.Files, repoLogs, mainLogs, repoDetails, httpClient, configuration.SignedCommitsRequired())
+		checks = applySuppressions(runChecks(checkContext, configuration), files, configuration, time.Now())
+	}
+
+	if *baselineWriteFlag != "" {
+		if err := baseline.Write(*baselineWriteFlag, filepath.Base(projectPath), checks); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(exitcodes.CouldNotCreateFile)
+		}
+	} else if *baselineFlag != "" {
+		checks = applyBaseline(checks, *baselineFlag, filepath.Base(projectPath))
+	}
+
+	printReport(checks, configuration.Format, configuration.Markers, *verboseFlag, skeletonRepository)
+
+	if configuration.Format == "text" {
+		printSkeleton(skeletonRepository, os.Stdout)
+		printChanges(changes, os.Stdout)
+	} else {
+		printSkeleton(skeletonRepository, os.Stderr)
+		printChanges(changes, os.Stderr)
+	}
 
-	printMessages(checks)
+	os.Exit(getExitCode(checks, configuration.FailOn))
 }
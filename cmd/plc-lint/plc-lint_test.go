@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bytes"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"internal/check"
+	"internal/config"
+	"internal/exitcodes"
+	"internal/fix"
+	"internal/message"
+	"internal/registry"
+	"internal/repofs"
+	repo "internal/repositorycontents"
+	"internal/suppression"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGetExitCode(t *testing.T) {
+	tests := map[string]struct {
+		expected map[string]int
+		statuses []check.Status
+	}{
+		"No results": {
+			expected: map[string]int{"fail": exitcodes.Ok, "incomplete": exitcodes.Ok, "error": exitcodes.Ok},
+			statuses: nil,
+		},
+		"Passed and skipped": {
+			expected: map[string]int{"fail": exitcodes.Ok, "incomplete": exitcodes.Ok, "error": exitcodes.Ok},
+			statuses: []check.Status{check.Pass, check.Skip},
+		},
+		"Failed": {
+			expected: map[string]int{"fail": exitcodes.ChecksFailed, "incomplete": exitcodes.Ok, "error": exitcodes.Ok},
+			statuses: []check.Status{check.Pass, check.Fail},
+		},
+		"Incomplete": {
+			expected: map[string]int{"fail": exitcodes.ChecksIncomplete, "incomplete": exitcodes.ChecksIncomplete, "error": exitcodes.Ok},
+			statuses: []check.Status{check.Incomplete, check.Pass},
+		},
+		"Error": {
+			expected: map[string]int{"fail": exitcodes.ChecksIncomplete, "incomplete": exitcodes.ChecksIncomplete, "error": exitcodes.ChecksIncomplete},
+			statuses: []check.Status{check.Error},
+		},
+		"Failed and error": {
+			expected: map[string]int{"fail": exitcodes.ChecksIncomplete, "incomplete": exitcodes.ChecksIncomplete, "error": exitcodes.ChecksIncomplete},
+			statuses: []check.Status{check.Error, check.Fail},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var checks []message.Message
+
+			for _, status := range test.statuses {
+				checks = append(checks, message.CreateMessage(status, "PLC1001", "Mock message"))
+			}
+
+			for failOn, expected := range test.expected {
+				actual := getExitCode(checks, failOn)
+
+				assert.Equal(t, expected, actual, "%s with fail-on '%s' expected exit code %v, got %v", name, failOn, expected, actual)
+			}
+		})
+	}
+}
+
+func TestGetMarkerForStatus(t *testing.T) {
+	markers := message.Marker{Error: "E", Fail: "F", Incomplete: "I", Pass: "P", Skip: "S"}
+
+	tests := map[check.Status]string{
+		check.Error:      "E",
+		check.Fail:       "F",
+		check.Incomplete: "I",
+		check.Pass:       "P",
+		check.Skip:       "S",
+	}
+
+	for status, expected := range tests {
+		actual := getMarkerForStatus(status, markers)
+
+		assert.Equal(t, expected, actual, "%v expected marker %v, got %v", status, expected, actual)
+	}
+}
+
+func TestPrintErrorSummary(t *testing.T) {
+	var output bytes.Buffer
+
+	printErrorSummary([]message.Message{
+		message.CreateErrorMessage("PLC12001", "Mock message 12001", "Mock reason 12001"),
+		message.CreateMessage(check.Fail, "PLC3001", "Mock message 3001"),
+		message.CreateErrorMessage("PLC9001", "Mock message 9001", "Mock reason 9001"),
+	}, &output)
+
+	assert.Equal(t, "\n2 check(s) could not be run:\n  PLC9001 Mock reason 9001\n  PLC12001 Mock reason 12001\n", output.String())
+}
+
+func TestPrintSymlinks(t *testing.T) {
+	var output bytes.Buffer
+
+	projectPath := t.TempDir()
+
+	assert.NoError(t, os.WriteFile(filepath.Join(projectPath, "LICENSE"), []byte("mock license"), 0o644))
+	assert.NoError(t, os.Symlink(filepath.Join(projectPath, "LICENSE"), filepath.Join(projectPath, "COPYING")))
+
+	printSymlinks(getFileList(projectPath, "", false, nil), &output)
+
+	assert.Equal(t, "'COPYING' is a symbolic link, which is not followed\n", output.String())
+}
+
+func TestPrintErrorSummaryWithoutErrors(t *testing.T) {
+	var output bytes.Buffer
+
+	printErrorSummary([]message.Message{message.CreateMessage(check.Pass, "PLC3001", "Mock message 3001")}, &output)
+
+	assert.Empty(t, output.String())
+}
+
+func TestValidateCodes(t *testing.T) {
+	assert.NoError(t, validateCodes(config.Config{Disable: []string{"PLC12"}, Enable: []string{"PLC12001"}}))
+	assert.EqualError(t, validateCodes(config.Config{Disable: []string{"PLC99", "PLC1999"}}),
+		"unknown PLC code or family 'PLC99' in configuration\nunknown PLC code or family 'PLC1999' in configuration")
+}
+
+func TestApplySuppressions(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	checks := []message.Message{
+		message.CreateMessage(check.Fail, "PLC5001", "Mock message 5001"),
+		message.CreateMessage(check.Fail, "PLC5002", "Mock message 5002"),
+	}
+
+	tests := map[string]struct {
+		configuration config.Config
+		files         map[string]string
+		status        map[string]check.Status
+	}{
+		"No suppression file": {
+			files:  map[string]string{},
+			status: map[string]check.Status{"PLC5001": check.Fail, "PLC5002": check.Fail, "PLC22003": check.Skip},
+		},
+		"All suppressions used": {
+			files:  map[string]string{suppression.FileName: "PLC5001 Mock reason\n"},
+			status: map[string]check.Status{"PLC5001": check.Suppressed, "PLC5002": check.Fail, "PLC22003": check.Pass},
+		},
+		"Unused suppression": {
+			files:  map[string]string{suppression.FileName: "PLC5001 Mock reason\nPLC1001 Mock reason\n"},
+			status: map[string]check.Status{"PLC5001": check.Suppressed, "PLC5002": check.Fail, "PLC22003": check.Fail},
+		},
+		"Suppression of an unknown code": {
+			files:  map[string]string{suppression.FileName: "PLC5001 Mock reason\nPLC9999 Mock reason\n"},
+			status: map[string]check.Status{"PLC5001": check.Suppressed, "PLC5002": check.Fail, "PLC22003": check.Pass},
+		},
+		"Suppression of a PLC22 code": {
+			files:  map[string]string{suppression.FileName: "PLC5001 Mock reason\nPLC22003 Mock reason\n"},
+			status: map[string]check.Status{"PLC5001": check.Suppressed, "PLC5002": check.Fail, "PLC22003": check.Pass},
+		},
+		"Unused suppressions not reported": {
+			configuration: config.Config{Disable: []string{"PLC22003"}},
+			files:         map[string]string{suppression.FileName: "PLC1001 Mock reason\n"},
+			status:        map[string]check.Status{"PLC5001": check.Fail, "PLC5002": check.Fail},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual := applySuppressions(checks, repofs.CreateFromMap(test.files), test.configuration, now)
+
+			assert.Len(t, actual, len(test.status))
+
+			for _, checkMessage := range actual {
+				assert.Equal(t, test.status[checkMessage.Code], checkMessage.Status, "%s expected status %v, got %v", checkMessage.Code, test.status[checkMessage.Code], checkMessage.Status)
+			}
+		})
+	}
+}
+
+func TestGetChanges(t *testing.T) {
+	checkContext := registry.Context{
+		Files:    repofs.CreateFromMap(map[string]string{".mdlrc": "changed"}),
+		Skeleton: repofs.CreateFromMap(map[string]string{".mdlrc": "original", ".yamllint": "original"}),
+	}
+
+	checks := []message.Message{
+		message.CreateMessage(check.Fail, "PLC4001", "Mock message 4001"),
+		message.CreateMessage(check.Fail, "PLC8001", "Mock message 8001"),
+		message.CreateMessage(check.Pass, "PLC9001", "Mock message 9001"),
+		message.CreateMessage(check.Suppressed, "PLC4002", "Mock message 4002"),
+	}
+
+	assert.Equal(t, []fix.Change{
+		{Code: "PLC8001", Content: "original", Description: "Restored `.mdlrc` from the skeleton repository", Path: ".mdlrc"},
+		{Code: "PLC4001", Description: "Created `app/` with a `.gitkeep` file", Path: "app/.gitkeep"},
+	}, getChanges(checkContext, checks))
+}
+
+func TestIndentDiff(t *testing.T) {
+	actual := indentDiff("--- component/.mdlrc\n+++ skeleton/.mdlrc\n@@ -1 +1 @@\n-line 1\n+line 2\n")
+
+	assert.Equal(t, "    --- component/.mdlrc\n    +++ skeleton/.mdlrc\n    @@ -1 +1 @@\n    -line 1\n    +line 2\n", actual)
+}
+
+func TestLoadAtRef(t *testing.T) {
+	projectPath := t.TempDir()
+	repository, _ := git.PlainInit(projectPath, false)
+	worktree, _ := repository.Worktree()
+	signature := &object.Signature{Name: "Mock Author", Email: "mock@example.com", When: time.Now()}
+
+	var hashes []string
+
+	for _, content := range []string{"first", "second"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(projectPath, "LICENSE"), []byte(content), 0o644))
+		_, _ = worktree.Add("LICENSE")
+		hash, err := worktree.Commit(content, &git.CommitOptions{Author: signature})
+		assert.NoError(t, err)
+		hashes = append(hashes, hash.String())
+	}
+
+	// The ref differs from HEAD, everything is read at the ref
+	ref := resolveRef(projectPath, "HEAD~1")
+
+	assert.Equal(t, hashes[0], ref)
+
+	content, _ := getFileList(projectPath, ref, false, nil).ReadFile("LICENSE")
+
+	assert.Equal(t, "first", content)
+
+	for _, logs := range [][]repo.LogEntry{loadRepoLogs(projectPath, ref), loadRepoBranchLogs(projectPath, "main", ref)} {
+		assert.Len(t, logs, 1)
+		assert.Equal(t, hashes[0], logs[0].Hash)
+	}
+
+	assert.Len(t, loadRepoLogs(projectPath, ""), 2)
+}
@@ -3,12 +3,23 @@ module gitlab.com/pipeline-components/org/pipeline-components-linter
 go 1.22
 
 require (
+	github.com/go-git/go-git/v5 v5.12.0
+	github.com/stretchr/testify v1.9.0
+	internal/baseline v0.1.0
 	internal/check v0.1.0
 	internal/checks v0.1.0
+	internal/config v0.1.0
 	internal/directorylist v0.1.0
 	internal/exitcodes v0.1.0
+	internal/fix v0.1.0
+	internal/httpclient v0.1.0
 	internal/message v0.1.0
+	internal/registry v0.1.0
+	internal/repofs v0.1.0
+	internal/report v0.1.0
 	internal/repositorycontents v0.1.0
+	internal/skeleton v0.1.0
+	internal/suppression v0.1.0
 )
 
 require (
@@ -16,34 +27,59 @@ require (
 	github.com/Microsoft/go-winio v0.6.1 // indirect
 	github.com/ProtonMail/go-crypto v1.0.0 // indirect
 	github.com/cloudflare/circl v1.3.7 // indirect
+	github.com/containerd/typeurl/v2 v2.1.1 // indirect
 	github.com/cyphar/filepath-securejoin v0.2.4 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/emirpasic/gods v1.18.1 // indirect
 	github.com/go-git/gcfg v1.5.1-0.20230307220236-3a3c6141e376 // indirect
 	github.com/go-git/go-billy/v5 v5.5.0 // indirect
-	github.com/go-git/go-git/v5 v5.12.0 // indirect
+	github.com/gogo/protobuf v1.3.2 // indirect
 	github.com/golang/groupcache v0.0.0-20210331224755-41bb18bfe9da // indirect
 	github.com/gomarkdown/markdown v0.0.0-20240730141124-034f12af3bf6 // indirect
 	github.com/jbenet/go-context v0.0.0-20150711004518-d14ea06fba99 // indirect
 	github.com/kevinburke/ssh_config v1.2.0 // indirect
+	github.com/moby/buildkit v0.13.2 // indirect
 	github.com/pjbgf/sha1cd v0.3.0 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/sergi/go-diff v1.3.2-0.20230802210424-5b0b94c5c0d3 // indirect
 	github.com/skeema/knownhosts v1.2.2 // indirect
 	github.com/xanzy/ssh-agent v0.3.3 // indirect
 	golang.org/x/crypto v0.21.0 // indirect
-	golang.org/x/mod v0.12.0 // indirect
-	golang.org/x/net v0.22.0 // indirect
+	golang.org/x/mod v0.13.0 // indirect
+	golang.org/x/net v0.23.0 // indirect
 	golang.org/x/sys v0.18.0 // indirect
-	golang.org/x/tools v0.13.0 // indirect
+	golang.org/x/tools v0.14.0 // indirect
+	google.golang.org/protobuf v1.31.0 // indirect
 	gopkg.in/warnings.v0 v0.1.2 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 	internal/asserts v0.1.0 // indirect
+	internal/dockerfile v0.1.0 // indirect
+	internal/markdownlint v0.1.0 // indirect
+	internal/textdiff v0.1.0 // indirect
+	internal/yamllint v0.1.0 // indirect
 )
 
 replace (
 	internal/asserts => ./internal/asserts
+	internal/baseline => ./internal/baseline
 	internal/check => ./internal/check
 	internal/checks => ./internal/checks
+	internal/config => ./internal/config
 	internal/directorylist => ./internal/directorylist
+	internal/dockerfile => ./internal/dockerfile
 	internal/exitcodes => ./internal/exitcodes
+	internal/fix => ./internal/fix
+	internal/httpclient => ./internal/httpclient
+	internal/httpclienttest => ./internal/httpclienttest
+	internal/markdownlint => ./internal/markdownlint
 	internal/message => ./internal/message
+	internal/registry => ./internal/registry
+	internal/repofs => ./internal/repofs
+	internal/report => ./internal/report
 	internal/repositorycontents => ./internal/repositorycontents
+	internal/skeleton => ./internal/skeleton
+	internal/suppression => ./internal/suppression
+	internal/textdiff => ./internal/textdiff
+	internal/yamllint => ./internal/yamllint
 )
@@ -1,46 +1,43 @@
 package asserts
 
 import (
-	"bytes"
 	"fmt"
 	"internal/check"
 	"internal/message"
+	"internal/repofs"
 )
 
 func CompareFiles(
-	files map[string]string,
-	repo map[string]string,
+	files repofs.RepoFS,
+	repo repofs.RepoFS,
 	fileCodes map[string]string,
 ) []message.Message {
-	var (
-		checkMessage string
-		messages     []message.Message
-		status       check.Status
-	)
+	var messages []message.Message
 
 	for targetFile := range fileCodes {
-		if _, repoFileExists := repo[targetFile]; !repoFileExists {
-			status = check.Error
-			checkMessage = fmt.Sprintf("The required `%[1]s` file is missing from the skeleton repository", targetFile)
-		} else {
-			for subjectFile, contents := range repo {
-				if subjectFile == targetFile {
-					checkMessage = fmt.Sprintf("The `%[1]s` file MUST be identical to `%[1]s` file in the skeleton repository", targetFile)
+		var result message.Message
 
-					if _, targetFileExists := files[targetFile]; !targetFileExists {
-						status = check.Skip
-					} else if bytes.Equal([]byte(files[targetFile]), []byte(contents)) {
-						status = check.Pass
-					} else {
-						status = check.Fail
-					}
+		checkMessage := fmt.Sprintf("The `%[1]s` file MUST be identical to `%[1]s` file in the skeleton repository", targetFile)
 
-					break
-				}
-			}
+		if _, repoFileExists := repo.Stat(targetFile); !repoFileExists {
+			result = message.CreateErrorMessage(
+				fileCodes[targetFile],
+				checkMessage,
+				fmt.Sprintf("The required `%s` file is missing from the skeleton repository", targetFile),
+			)
+		} else if _, targetFileExists := files.Stat(targetFile); !targetFileExists {
+			result = message.CreateMessage(check.Skip, fileCodes[targetFile], checkMessage)
+		} else if contents, err := repo.ReadFile(targetFile); err != nil {
+			result = message.CreateErrorMessage(fileCodes[targetFile], checkMessage, err.Error())
+		} else if fileContents, err := files.ReadFile(targetFile); err != nil {
+			result = message.CreateErrorMessage(fileCodes[targetFile], checkMessage, err.Error())
+		} else if fileContents == contents {
+			result = message.CreateMessage(check.Pass, fileCodes[targetFile], checkMessage)
+		} else {
+			result = CreateDiffMessage(fileCodes[targetFile], checkMessage, targetFile, fileContents, contents)
 		}
 
-		messages = append(messages, message.CreateMessage(status, fileCodes[targetFile], checkMessage))
+		messages = append(messages, result)
 	}
 
 	return messages
@@ -0,0 +1,21 @@
+package asserts
+
+import (
+	"internal/check"
+	"internal/message"
+	"internal/textdiff"
+)
+
+// CreateDiffMessage creates a failure message for content in the component
+// that differs from its counterpart in the skeleton repository. The message
+// carries a unified diff from the component to the skeleton.
+func CreateDiffMessage(code string, checkMessage string, name string, content string, skeletonContent string) message.Message {
+	if textdiff.IsWhitespaceOnly(content, skeletonContent) {
+		checkMessage += textdiff.WhitespaceOnlyNote
+	}
+
+	result := message.CreateMessage(check.Fail, code, checkMessage)
+	result.Diff = textdiff.Unified("component/"+name, content, "skeleton/"+name, skeletonContent)
+
+	return result
+}
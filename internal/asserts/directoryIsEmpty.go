@@ -1,14 +1,15 @@
 package asserts
 
-import "strings"
+import (
+	"internal/repofs"
+	"strings"
+)
 
-func DirectoryIsEmpty(files map[string]string, targetFile string) bool {
+func DirectoryIsEmpty(files repofs.RepoFS, targetFile string) bool {
 	empty := true
 
-	for key := range files {
-		path := strings.Split(key, "/")[0] + "/"
-
-		if path == targetFile && key != targetFile {
+	for _, key := range files.List() {
+		if strings.HasPrefix(key, targetFile) && key != targetFile {
 			empty = false
 			break
 		}
@@ -4,12 +4,11 @@ import (
 	"fmt"
 	"internal/check"
 	"internal/message"
-	"maps"
-	"slices"
+	"internal/repofs"
 )
 
 func FileExists(
-	files map[string]string,
+	files repofs.RepoFS,
 	fileCodes map[string]string,
 ) []message.Message {
 	var (
@@ -18,19 +17,20 @@ func FileExists(
 		status       check.Status
 	)
 
-	fileNames := slices.Collect(maps.Keys(files))
-	requiredFiles := slices.Collect(maps.Keys(fileCodes))
-
-	for _, file := range requiredFiles {
-		checkMessage = fmt.Sprintf(fmt.Sprintf("The repository MUST contain a `%s` file", file))
+	for file, code := range fileCodes {
+		checkMessage = fmt.Sprintf("The repository MUST contain a `%s` file", file)
 
 		status = check.Fail
 
-		if slices.Contains(fileNames, file) {
+		if info, ok := files.Stat(file); ok && info.IsSymlink {
+			// The link is not followed, so whether it points to a file is not known
+			messages = append(messages, message.CreateErrorMessage(code, checkMessage, fmt.Sprintf("`%s` is a symbolic link, which is not followed", file)))
+			continue
+		} else if ok && !info.IsDir {
 			status = check.Pass
 		}
 
-		messages = append(messages, message.CreateMessage(status, fileCodes[file], checkMessage))
+		messages = append(messages, message.CreateMessage(status, code, checkMessage))
 	}
 
 	return messages
@@ -0,0 +1,22 @@
+package asserts
+
+import (
+	"internal/repofs"
+	"path"
+	"strings"
+)
+
+// ListFolderFiles returns the sorted paths of all files in the given folder
+// (and its sub-folders). Directories and `.gitkeep` placeholders are left out.
+func ListFolderFiles(files repofs.RepoFS, targetFolder string) []string {
+	var folderFiles []string
+
+	// The listing is already sorted
+	for _, file := range files.List() {
+		if strings.HasPrefix(file, targetFolder) && !strings.HasSuffix(file, "/") && path.Base(file) != ".gitkeep" {
+			folderFiles = append(folderFiles, file)
+		}
+	}
+
+	return folderFiles
+}
@@ -0,0 +1,139 @@
+package baseline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"internal/check"
+	"internal/message"
+	"os"
+	"sort"
+)
+
+// Entry is a single failure that is recorded in a baseline. The message is
+// kept to show what was fixed, the fingerprint is used to match a failure.
+type Entry struct {
+	Code        string `json:"code"`
+	Fingerprint string `json:"fingerprint"`
+	Message     string `json:"message"`
+}
+
+// Baseline holds the recorded failures of each component, by component name,
+// so one file can be shared by several components.
+type Baseline map[string][]Entry
+
+// Apply leaves out the failures that are in the given entries. A fingerprint
+// that is recorded a number of times only hides that many failures, so new
+// occurrences of the same failure are still reported. Entries that no longer
+// match a failure have been fixed, those are reported as passed. That is only
+// done for codes that were checked completely, as a disabled check or a check
+// that did not finish says nothing about whether a failure was fixed.
+func Apply(messages []message.Message, entries []Entry) []message.Message {
+	var applied []message.Message
+
+	checked := map[string]bool{}
+	recorded := map[string]int{}
+	unfinished := map[string]bool{}
+
+	for _, entry := range entries {
+		recorded[entry.Code+entry.Fingerprint]++
+	}
+
+	for _, checkMessage := range messages {
+		key := checkMessage.Code + checkMessage.Fingerprint()
+
+		checked[checkMessage.Code] = true
+
+		if checkMessage.Status == check.Error || checkMessage.Status == check.Incomplete {
+			unfinished[checkMessage.Code] = true
+		}
+
+		if checkMessage.Status == check.Fail && recorded[key] > 0 {
+			recorded[key]--
+		} else {
+			applied = append(applied, checkMessage)
+		}
+	}
+
+	for _, entry := range entries {
+		key := entry.Code + entry.Fingerprint
+
+		if recorded[key] > 0 && checked[entry.Code] && !unfinished[entry.Code] {
+			recorded[key]--
+
+			applied = append(applied, message.CreateMessage(
+				check.Pass,
+				entry.Code,
+				fmt.Sprintf("Fixed since the baseline: %s", entry.Message),
+			))
+		}
+	}
+
+	return applied
+}
+
+// Create records the failures in the given messages. A failure that occurs
+// more than once (like the same linting violation on several lines) is
+// recorded once for every occurrence.
+func Create(messages []message.Message) []Entry {
+	entries := []Entry{}
+
+	for _, checkMessage := range messages {
+		if checkMessage.Status == check.Fail {
+			entries = append(entries, Entry{Code: checkMessage.Code, Fingerprint: checkMessage.Fingerprint(), Message: checkMessage.Text()})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Code != entries[j].Code {
+			return entries[i].Code < entries[j].Code
+		}
+
+		return entries[i].Message < entries[j].Message
+	})
+
+	return entries
+}
+
+// Read loads the baseline from the given file
+func Read(path string) (Baseline, error) {
+	baseline := Baseline{}
+
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return baseline, fmt.Errorf("could not read baseline '%s': %w", path, err)
+	}
+
+	if err = json.Unmarshal(content, &baseline); err != nil {
+		return baseline, fmt.Errorf("could not read baseline '%s': %w", path, err)
+	}
+
+	return baseline, nil
+}
+
+// Write records the failures in the given messages for the given component.
+// The entries of other components in an existing file are kept.
+func Write(path string, component string, messages []message.Message) error {
+	baseline := Baseline{}
+
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		if baseline, err = Read(path); err != nil {
+			return err
+		}
+	}
+
+	baseline[component] = Create(messages)
+
+	content, err := json.MarshalIndent(baseline, "", "  ")
+
+	if err == nil {
+		err = os.WriteFile(path, append(content, '\n'), 0o644)
+	}
+
+	if err != nil {
+		return fmt.Errorf("could not write baseline '%s': %w", path, err)
+	}
+
+	return nil
+}
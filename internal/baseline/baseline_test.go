@@ -0,0 +1,115 @@
+package baseline
+
+import (
+	"github.com/stretchr/testify/assert"
+	"internal/check"
+	"internal/message"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCreate(t *testing.T) {
+	entries := Create([]message.Message{
+		message.CreateMessage(check.Fail, "PLC6001", "Mock message 6001"),
+		message.CreateMessage(check.Pass, "PLC6002", "Mock message 6002"),
+		message.CreateLocatedMessage(check.Fail, "PLC13001", "", 3, 0, "MD001 Mock message"),
+		message.CreateLocatedMessage(check.Fail, "PLC13001", "", 9, 0, "MD001 Mock message"),
+		message.CreateMessage(check.Suppressed, "PLC7001", "Mock message 7001"),
+	})
+
+	// Each occurrence of the same failure is recorded
+	assert.Len(t, entries, 3)
+	assert.Equal(t, "PLC13001", entries[0].Code)
+	assert.Equal(t, "Line 3: MD001 Mock message", entries[0].Message)
+	assert.Equal(t, "Line 9: MD001 Mock message", entries[1].Message)
+	assert.Equal(t, entries[0].Fingerprint, entries[1].Fingerprint)
+	assert.Equal(t, "PLC6001", entries[2].Code)
+}
+
+func TestApply(t *testing.T) {
+	entries := Create([]message.Message{
+		message.CreateMessage(check.Fail, "PLC6001", "Mock message 6001"),
+		message.CreateLocatedMessage(check.Fail, "PLC13001", "", 3, 0, "MD001 Mock message"),
+	})
+
+	actual := Apply([]message.Message{
+		message.CreateMessage(check.Pass, "PLC6001", "Mock message 6001"),
+		message.CreateLocatedMessage(check.Fail, "PLC13001", "", 5, 0, "MD001 Mock message"),
+		message.CreateLocatedMessage(check.Fail, "PLC13001", "", 8, 0, "MD002 Mock message"),
+	}, entries)
+
+	expected := []message.Message{
+		message.CreateMessage(check.Pass, "PLC6001", "Mock message 6001"),
+		message.CreateLocatedMessage(check.Fail, "PLC13001", "", 8, 0, "MD002 Mock message"),
+		message.CreateMessage(check.Pass, "PLC6001", "Fixed since the baseline: Mock message 6001"),
+	}
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestApplyOccurrences(t *testing.T) {
+	entries := Create([]message.Message{
+		message.CreateLocatedMessage(check.Fail, "PLC13001", "", 3, 0, "MD013 Mock message"),
+	})
+
+	actual := Apply([]message.Message{
+		message.CreateLocatedMessage(check.Fail, "PLC13001", "", 4, 0, "MD013 Mock message"),
+		message.CreateLocatedMessage(check.Fail, "PLC13001", "", 9, 0, "MD013 Mock message"),
+	}, entries)
+
+	// Only as many failures as were recorded are left out
+	expected := []message.Message{
+		message.CreateLocatedMessage(check.Fail, "PLC13001", "", 9, 0, "MD013 Mock message"),
+	}
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestApplyUnfinished(t *testing.T) {
+	entries := Create([]message.Message{
+		message.CreateMessage(check.Fail, "PLC2001", "Mock message 2001"),
+		message.CreateMessage(check.Fail, "PLC6001", "Mock message 6001"),
+		message.CreateMessage(check.Fail, "PLC7001", "Mock message 7001"),
+	})
+
+	actual := Apply([]message.Message{
+		message.CreateIncompleteMessage("PLC2001", "Mock message 2001", "Mock reason"),
+		message.CreateErrorMessage("PLC6001", "Mock message 6001", "Mock reason"),
+	}, entries)
+
+	// A check that did not finish, or was not run (PLC7), has not fixed anything
+	expected := []message.Message{
+		message.CreateIncompleteMessage("PLC2001", "Mock message 2001", "Mock reason"),
+		message.CreateErrorMessage("PLC6001", "Mock message 6001", "Mock reason"),
+	}
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestWriteAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+
+	assert.NoError(t, Write(path, "component-a", []message.Message{message.CreateMessage(check.Fail, "PLC6001", "Mock message")}))
+	assert.NoError(t, Write(path, "component-b", []message.Message{message.CreateMessage(check.Pass, "PLC6001", "Mock message")}))
+
+	actual, err := Read(path)
+
+	assert.NoError(t, err)
+	assert.Len(t, actual["component-a"], 1)
+	assert.Equal(t, []Entry{}, actual["component-b"])
+}
+
+func TestReadInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+
+	_, err := Read(path)
+
+	assert.ErrorContains(t, err, "could not read baseline '"+path+"'")
+
+	assert.NoError(t, os.WriteFile(path, []byte("[]"), 0o644))
+
+	_, err = Read(path)
+
+	assert.ErrorContains(t, err, "could not read baseline '"+path+"'")
+}
@@ -3,9 +3,14 @@ package check
 type Status int64
 
 const (
-	Error        = -1
+	// Error is used when a check could not be run, for instance because the
+	// skeleton repository is incomplete or a configuration file is invalid.
+	Error Status = -1
 	Pass  Status = iota
 	Fail
 	Skip
 	Incomplete
+	// Suppressed is used for a failure that is deliberate, as explained in the
+	// `.plc-lint-ignore` file of a component.
+	Suppressed
 )
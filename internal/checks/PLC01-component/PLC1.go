@@ -2,11 +2,12 @@ package checks
 
 import (
 	"internal/check"
+	"internal/dockerfile"
 	"internal/message"
+	"internal/registry"
+	"internal/repofs"
 	repo "internal/repositorycontents"
 	"path/filepath"
-	"regexp"
-	"strings"
 )
 
 func listCodes() map[string]string {
@@ -17,7 +18,13 @@ func listCodes() map[string]string {
 	}
 }
 
-func PLC1(projectPath string, files map[string]string, repoLogs []repo.LogEntry) []message.Message {
+func init() {
+	registry.Register(registry.CreateCheck("PLC1", "Component", "", listCodes(), func(checkContext registry.Context) []message.Message {
+		return PLC1(checkContext.ProjectPath, checkContext.Files, checkContext.Logs)
+	}))
+}
+
+func PLC1(projectPath string, files repofs.RepoFS, repoLogs []repo.LogEntry) []message.Message {
 	var (
 		messages []message.Message
 	)
@@ -29,28 +36,27 @@ func PLC1(projectPath string, files map[string]string, repoLogs []repo.LogEntry)
 		status[code] = check.Skip
 	}
 
-	if projectPath != "" && len(files) > 0 {
+	if projectPath != "" && len(files.List()) > 0 {
 		status["PLC1001"] = check.Pass
 	}
 
-	if _, fileExists := files["Dockerfile"]; fileExists {
-		dockerfileLines := strings.Split(files["Dockerfile"], "\n")
-		for _, line := range dockerfileLines {
-			if strings.Contains(line, "ENV DEFAULTCMD") {
-				mainCommandPattern := regexp.MustCompile(`^\s*ENV DEFAULTCMD\s*=?\s*["']?(?P<Command>.+?)["']?$`)
-				if mainCommandPattern.MatchString(line) {
-					status["PLC1002"] = check.Fail
+	if content, err := files.ReadFile("Dockerfile"); err == nil {
+		parsed, err := dockerfile.Parse(content)
+
+		if err == nil {
+			declarations := parsed.Env("DEFAULTCMD")
 
-					matches := mainCommandPattern.FindStringSubmatch(line)
-					mainCommand := matches[mainCommandPattern.SubexpIndex("Command")]
+			if len(declarations) > 0 {
+				status["PLC1002"] = check.Fail
 
-					if mainCommand == filepath.Base(projectPath) {
-						status["PLC1002"] = check.Pass
-					}
+				// The last declaration is the one that ends up in the image
+				mainCommand := declarations[len(declarations)-1].Value
+
+				if mainCommand == filepath.Base(projectPath) {
+					status["PLC1002"] = check.Pass
 				}
 			}
 		}
-
 	}
 
 	if repoLogs == nil {
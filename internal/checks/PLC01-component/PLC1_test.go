@@ -3,6 +3,7 @@ package checks
 import (
 	"github.com/stretchr/testify/assert"
 	"internal/check"
+	"internal/repofs"
 	repo "internal/repositorycontents"
 	"testing"
 )
@@ -73,7 +74,7 @@ func TestPLC1(t *testing.T) {
 		"Path is provided, Dockerfile exist, Dockerfile has valid ENV DEFAULTCMD that does not match the folder name": {
 			projectPath: "some/path",
 			files: map[string]string{
-				"Dockerfile": "ENV DEFAULTCMD foo",
+				"Dockerfile": "FROM alpine\nENV DEFAULTCMD foo",
 			},
 			repoLogs: nil,
 			status: map[string]check.Status{
@@ -85,7 +86,43 @@ func TestPLC1(t *testing.T) {
 		"Path is provided, Dockerfile exist, Dockerfile has valid ENV DEFAULTCMD that matches the folder name": {
 			projectPath: "some/path",
 			files: map[string]string{
-				"Dockerfile": "ENV DEFAULTCMD path",
+				"Dockerfile": "FROM alpine\nENV DEFAULTCMD path",
+			},
+			repoLogs: nil,
+			status: map[string]check.Status{
+				"PLC1001": check.Pass,
+				"PLC1002": check.Pass,
+				"PLC1003": check.Fail,
+			},
+		},
+		"Path is provided, Dockerfile exist, Dockerfile has a multi-line ENV DEFAULTCMD that matches the folder name": {
+			projectPath: "some/path",
+			files: map[string]string{
+				"Dockerfile": "FROM alpine\nENV FOO=bar \\\n    DEFAULTCMD=\"path\"",
+			},
+			repoLogs: nil,
+			status: map[string]check.Status{
+				"PLC1001": check.Pass,
+				"PLC1002": check.Pass,
+				"PLC1003": check.Fail,
+			},
+		},
+		"Path is provided, Dockerfile exist, Dockerfile has an ARG-interpolated ENV DEFAULTCMD that does not match the folder name": {
+			projectPath: "some/path",
+			files: map[string]string{
+				"Dockerfile": "FROM alpine\nARG COMMAND=foo\nENV DEFAULTCMD=${COMMAND}",
+			},
+			repoLogs: nil,
+			status: map[string]check.Status{
+				"PLC1001": check.Pass,
+				"PLC1002": check.Fail,
+				"PLC1003": check.Fail,
+			},
+		},
+		"Path is provided, Dockerfile exist, Dockerfile has an ARG-interpolated ENV DEFAULTCMD that matches the folder name": {
+			projectPath: "some/path",
+			files: map[string]string{
+				"Dockerfile": "FROM alpine\nARG COMMAND=path\nENV DEFAULTCMD=${COMMAND}",
 			},
 			repoLogs: nil,
 			status: map[string]check.Status{
@@ -97,7 +134,7 @@ func TestPLC1(t *testing.T) {
 		"Path is provided, Dockerfile exist, Dockerfile has matching ENV DEFAULTCMD, but no repo logs": {
 			projectPath: "some/path",
 			files: map[string]string{
-				"Dockerfile": "ENV DEFAULTCMD path",
+				"Dockerfile": "FROM alpine\nENV DEFAULTCMD path",
 			},
 			repoLogs: nil,
 			status: map[string]check.Status{
@@ -109,7 +146,7 @@ func TestPLC1(t *testing.T) {
 		"Path is provided, Dockerfile exist, Dockerfile has matching ENV DEFAULTCMD, and repo logs exist": {
 			projectPath: "some/path",
 			files: map[string]string{
-				"Dockerfile": "ENV DEFAULTCMD path",
+				"Dockerfile": "FROM alpine\nENV DEFAULTCMD path",
 			},
 			repoLogs: []repo.LogEntry{},
 			status: map[string]check.Status{
@@ -122,7 +159,7 @@ func TestPLC1(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			messages := PLC1(test.projectPath, test.files, test.repoLogs)
+			messages := PLC1(test.projectPath, repofs.CreateFromMap(test.files), test.repoLogs)
 
 			for _, message := range messages {
 				assert.Equal(t, test.status[message.Code], message.Status)
@@ -1,12 +1,14 @@
 package checks
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"internal/check"
+	"internal/httpclient"
 	"internal/message"
+	"internal/registry"
 	"internal/repositorycontents"
-	"io"
-	"net/http"
 	"net/url"
 	"strings"
 )
@@ -18,8 +20,6 @@ type branch struct {
 	WebUrl    string `json:"web_url"`
 }
 
-var httpGet = http.Get
-
 func listCodes() map[string]string {
 	return map[string]string{
 		"PLC2001": "The repository MUST be hosted under https://gitlab.com/pipeline-components/",
@@ -30,6 +30,12 @@ func listCodes() map[string]string {
 	}
 }
 
+func init() {
+	registry.Register(registry.CreateCheck("PLC2", "Repository", "", listCodes(), func(checkContext registry.Context) []message.Message {
+		return PLC2(checkContext.Context, checkContext.HTTPClient, checkContext.RepoDetails)
+	}))
+}
+
 func normalizeGitUrl(url string) string {
 	if strings.HasPrefix(url, "git@") {
 		url = strings.Replace(url, ":", "/", 1)
@@ -39,12 +45,13 @@ func normalizeGitUrl(url string) string {
 	return url
 }
 
-func PLC2(repoDetails repositorycontents.Details) []message.Message {
+func PLC2(ctx context.Context, client *httpclient.Client, repoDetails repositorycontents.Details) []message.Message {
 	var (
 		messages []message.Message
 	)
 
 	status := map[string]check.Status{}
+	reasons := map[string]string{}
 	codes := listCodes()
 
 	for code := range codes {
@@ -63,36 +70,34 @@ func PLC2(repoDetails repositorycontents.Details) []message.Message {
 
 				apiUrl := "https://gitlab.com/api/v4/projects/" + url.QueryEscape(project) + "/repository/branches"
 
-				response, err := httpGet(apiUrl)
-
-				if err == nil {
-					if response.StatusCode >= 200 && response.StatusCode <= 399 {
-						status["PLC2002"] = check.Pass
-						status["PLC2003"] = check.Fail
-						status["PLC2004"] = check.Fail
-						status["PLC2005"] = check.Fail
-
-						var bodyBytes []byte
+				response, err := client.Get(ctx, apiUrl)
 
-						if response.Body != nil {
-							bodyBytes, err = io.ReadAll(response.Body)
-						}
+				if err != nil {
+					// A request that failed (or was stopped) says nothing about the repository
+					for _, code := range []string{"PLC2002", "PLC2003", "PLC2004", "PLC2005"} {
+						status[code] = check.Incomplete
+						reasons[code] = fmt.Sprintf("The repository could not be looked up: %v", err)
+					}
+				} else if response.StatusCode >= 200 && response.StatusCode <= 399 {
+					status["PLC2002"] = check.Pass
+					status["PLC2003"] = check.Fail
+					status["PLC2004"] = check.Fail
+					status["PLC2005"] = check.Fail
 
-						var branches []branch
+					var branches []branch
 
-						err = json.Unmarshal(bodyBytes, &branches)
+					err = json.Unmarshal(response.Body, &branches)
 
-						if err == nil {
-							for _, branch := range branches {
-								if branch.Default == true {
-									status["PLC2003"] = check.Pass
+					if err == nil {
+						for _, branch := range branches {
+							if branch.Default == true {
+								status["PLC2003"] = check.Pass
 
-									if branch.Name == "main" {
-										status["PLC2004"] = check.Pass
-									}
-									if branch.Protected == true {
-										status["PLC2005"] = check.Pass
-									}
+								if branch.Name == "main" {
+									status["PLC2004"] = check.Pass
+								}
+								if branch.Protected == true {
+									status["PLC2005"] = check.Pass
 								}
 							}
 						}
@@ -103,7 +108,11 @@ func PLC2(repoDetails repositorycontents.Details) []message.Message {
 	}
 
 	for code, checkStatus := range status {
-		messages = append(messages, message.CreateMessage(checkStatus, code, codes[code]))
+		if checkStatus == check.Incomplete {
+			messages = append(messages, message.CreateIncompleteMessage(code, codes[code], reasons[code]))
+		} else {
+			messages = append(messages, message.CreateMessage(checkStatus, code, codes[code]))
+		}
 	}
 
 	return messages
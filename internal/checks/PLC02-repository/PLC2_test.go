@@ -1,14 +1,16 @@
 package checks
 
 import (
-	"bytes"
-	"errors"
+	"context"
 	"github.com/stretchr/testify/assert"
 	"internal/check"
+	"internal/httpclient"
+	"internal/httpclienttest"
 	"internal/repositorycontents"
-	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 var mockDetails = repositorycontents.Details{
@@ -18,21 +20,22 @@ var mockDetails = repositorycontents.Details{
 	},
 }
 
-var mockError = errors.New("mock error")
-
-var mockHttpGet = func(url string) (*http.Response, error) {
-	return nil, nil
+// respond creates a handler that answers every request with the given status and body
+func respond(statusCode int, body string) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(statusCode)
+		_, _ = writer.Write([]byte(body))
+	}
 }
 
 func TestPLC2(t *testing.T) {
 	tests := map[string]struct {
-		details      repositorycontents.Details
-		mockFunction func(url string) (*http.Response, error)
-		status       map[string]check.Status
+		details repositorycontents.Details
+		handler http.HandlerFunc
+		status  map[string]check.Status
 	}{
 		"Repository does not have remote(s)": {
-			details:      nil,
-			mockFunction: mockHttpGet,
+			details: nil,
 			status: map[string]check.Status{
 				"PLC2001": check.Skip,
 				"PLC2002": check.Skip,
@@ -48,7 +51,6 @@ func TestPLC2(t *testing.T) {
 					Branches: []string{"master"},
 				},
 			},
-			mockFunction: mockHttpGet,
 			status: map[string]check.Status{
 				"PLC2001": check.Fail,
 				"PLC2002": check.Skip,
@@ -64,22 +66,27 @@ func TestPLC2(t *testing.T) {
 					Branches: []string{"master"},
 				},
 			},
-			mockFunction: func(url string) (*http.Response, error) {
-				return nil, mockError
-			},
 			status: map[string]check.Status{
 				"PLC2001": check.Pass,
-				"PLC2002": check.Fail,
-				"PLC2003": check.Skip,
-				"PLC2004": check.Skip,
-				"PLC2005": check.Skip,
+				"PLC2002": check.Incomplete,
+				"PLC2003": check.Incomplete,
+				"PLC2004": check.Incomplete,
+				"PLC2005": check.Incomplete,
 			},
 		},
 		"Repository has remote under https://gitlab.com/pipeline-components": {
 			details: mockDetails,
-			mockFunction: func(url string) (*http.Response, error) {
-				return nil, mockError
+			status: map[string]check.Status{
+				"PLC2001": check.Pass,
+				"PLC2002": check.Incomplete,
+				"PLC2003": check.Incomplete,
+				"PLC2004": check.Incomplete,
+				"PLC2005": check.Incomplete,
 			},
+		},
+		"Repository under gitlab.com/pipeline-components is not publicly accessible": {
+			details: mockDetails,
+			handler: respond(404, `{"message": "404 Project Not Found"}`),
 			status: map[string]check.Status{
 				"PLC2001": check.Pass,
 				"PLC2002": check.Fail,
@@ -90,11 +97,7 @@ func TestPLC2(t *testing.T) {
 		},
 		"Repository under gitlab.com/pipeline-components is publicly accessible": {
 			details: mockDetails,
-			mockFunction: func(url string) (*http.Response, error) {
-				return &http.Response{
-					StatusCode: 200,
-				}, nil
-			},
+			handler: respond(200, ""),
 			status: map[string]check.Status{
 				"PLC2001": check.Pass,
 				"PLC2002": check.Pass,
@@ -105,12 +108,7 @@ func TestPLC2(t *testing.T) {
 		},
 		"Publicly accessible repository under gitlab.com/pipeline-components without default branch": {
 			details: mockDetails,
-			mockFunction: func(url string) (*http.Response, error) {
-				return &http.Response{
-					StatusCode: 200,
-					Body:       io.NopCloser(bytes.NewBufferString("[]")),
-				}, nil
-			},
+			handler: respond(200, "[]"),
 			status: map[string]check.Status{
 				"PLC2001": check.Pass,
 				"PLC2002": check.Pass,
@@ -121,16 +119,7 @@ func TestPLC2(t *testing.T) {
 		},
 		"Publicly accessible repository under gitlab.com/pipeline-components with unprotected non-'main' default branch": {
 			details: mockDetails,
-			mockFunction: func(url string) (*http.Response, error) {
-				resp := &http.Response{
-					StatusCode: 200,
-					Body: io.NopCloser(bytes.NewBufferString(
-						`[{"default": true, "name": "mock-branch", "protected": false, "web_url": "https://gitlab.com/pipeline-components/foo/-/tree/mock-branch"}]`),
-					),
-				}
-
-				return resp, nil
-			},
+			handler: respond(200, `[{"default": true, "name": "mock-branch", "protected": false, "web_url": "https://gitlab.com/pipeline-components/foo/-/tree/mock-branch"}]`),
 			status: map[string]check.Status{
 				"PLC2001": check.Pass,
 				"PLC2002": check.Pass,
@@ -141,16 +130,7 @@ func TestPLC2(t *testing.T) {
 		},
 		"Publicly accessible repository under gitlab.com/pipeline-components with unprotected 'main' default branch": {
 			details: mockDetails,
-			mockFunction: func(url string) (*http.Response, error) {
-				resp := &http.Response{
-					StatusCode: 200,
-					Body: io.NopCloser(bytes.NewBufferString(
-						`[{"default": true, "name": "main", "protected": false, "web_url": "https://gitlab.com/pipeline-components/foo/-/tree/mock-branch"}]`),
-					),
-				}
-
-				return resp, nil
-			},
+			handler: respond(200, `[{"default": true, "name": "main", "protected": false, "web_url": "https://gitlab.com/pipeline-components/foo/-/tree/mock-branch"}]`),
 			status: map[string]check.Status{
 				"PLC2001": check.Pass,
 				"PLC2002": check.Pass,
@@ -161,16 +141,7 @@ func TestPLC2(t *testing.T) {
 		},
 		"Publicly accessible repository under gitlab.com/pipeline-components with protected 'main' default branch": {
 			details: mockDetails,
-			mockFunction: func(url string) (*http.Response, error) {
-				resp := &http.Response{
-					StatusCode: 200,
-					Body: io.NopCloser(bytes.NewBufferString(
-						`[{"default": true, "name": "main", "protected": true, "web_url": "https://gitlab.com/pipeline-components/foo/-/tree/mock-branch"}]`),
-					),
-				}
-
-				return resp, nil
-			},
+			handler: respond(200, `[{"default": true, "name": "main", "protected": true, "web_url": "https://gitlab.com/pipeline-components/foo/-/tree/mock-branch"}]`),
 			status: map[string]check.Status{
 				"PLC2001": check.Pass,
 				"PLC2002": check.Pass,
@@ -181,16 +152,21 @@ func TestPLC2(t *testing.T) {
 		},
 	}
 
-	originalFunction := httpGet
-	defer func() { httpGet = originalFunction }()
-
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			// Arrange
-			httpGet = test.mockFunction
+			server := httptest.NewServer(test.handler)
+			client := httpclienttest.CreateClient(server)
+
+			// Without a handler, the request fails because nothing is listening
+			if test.handler == nil {
+				server.Close()
+			} else {
+				defer server.Close()
+			}
 
 			// Act
-			messages := PLC2(test.details)
+			messages := PLC2(context.Background(), client, test.details)
 
 			// Assert
 			for _, message := range messages {
@@ -204,3 +180,59 @@ func TestPLC2(t *testing.T) {
 		})
 	}
 }
+
+func TestPLC2Stopped(t *testing.T) {
+	server := httptest.NewServer(respond(http.StatusOK, "[]"))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// A request that was stopped does not fail the repository
+	expected := map[string]check.Status{
+		"PLC2001": check.Pass,
+		"PLC2002": check.Incomplete,
+		"PLC2003": check.Incomplete,
+		"PLC2004": check.Incomplete,
+		"PLC2005": check.Incomplete,
+	}
+
+	messages := PLC2(ctx, httpclienttest.CreateClient(server), mockDetails)
+
+	for _, message := range messages {
+		assert.Equal(t, expected[message.Code], message.Status, "%s expected status %v, got %v", message.Code, expected[message.Code], message.Status)
+
+		if message.Status == check.Incomplete {
+			assert.Contains(t, message.Reason, "The repository could not be looked up: ")
+		}
+	}
+}
+
+func TestPLC2Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		select {
+		case <-request.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client, _ := httpclient.CreateClient(httpclient.Options{Timeout: 10 * time.Millisecond, Transport: httpclienttest.CreateTransport(server)})
+
+	// A request that times out does not fail the repository, even when the check goes on
+	expected := map[string]check.Status{
+		"PLC2001": check.Pass,
+		"PLC2002": check.Incomplete,
+		"PLC2003": check.Incomplete,
+		"PLC2004": check.Incomplete,
+		"PLC2005": check.Incomplete,
+	}
+
+	for _, message := range PLC2(context.Background(), client, mockDetails) {
+		assert.Equal(t, expected[message.Code], message.Status, "%s expected status %v, got %v", message.Code, expected[message.Code], message.Status)
+
+		if message.Status == check.Incomplete {
+			assert.Contains(t, message.Reason, "Client.Timeout exceeded")
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package checks
+
+import (
+	"fmt"
+	"internal/check"
+	"internal/message"
+	"internal/registry"
+	repo "internal/repositorycontents"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxSubjectLength = 72
+	shortHashLength  = 7
+)
+
+func listCodes() map[string]string {
+	return map[string]string{
+		"PLC3001": "Commit messages MUST start with a subject line",
+		"PLC3002": "The subject line of a commit message MUST NOT be longer than 72 characters",
+		"PLC3003": "The subject line of a commit message MUST be followed by a blank line",
+		"PLC3004": "The `main` branch MUST NOT contain merge commits",
+		"PLC3005": "Commits MUST have an author email address",
+		"PLC3006": "Commits MUST be signed",
+	}
+}
+
+func init() {
+	registry.Register(registry.CreateCheck("PLC3", "Commits", "", listCodes(), func(checkContext registry.Context) []message.Message {
+		return PLC3(checkContext.Logs, checkContext.MainLogs, checkContext.RequireSignedCommits)
+	}))
+}
+
+// describeCommit names a commit in a message, by its short hash and subject
+func describeCommit(logEntry repo.LogEntry, subject string) string {
+	hash := logEntry.Hash
+
+	if len(hash) > shortHashLength {
+		hash = hash[:shortHashLength]
+	}
+
+	return fmt.Sprintf("commit `%s` %q", hash, subject)
+}
+
+// PLC3 checks the commits in the history. The signature check is skipped,
+// unless signed commits are required in the configuration.
+func PLC3(logs []repo.LogEntry, mainLogs []repo.LogEntry, requireSignedCommits bool) []message.Message {
+	var (
+		messages []message.Message
+	)
+
+	status := map[string]check.Status{}
+	codes := listCodes()
+
+	for code := range codes {
+		status[code] = check.Skip
+	}
+
+	if len(logs) > 0 {
+		for _, code := range []string{"PLC3001", "PLC3002", "PLC3003", "PLC3005"} {
+			status[code] = check.Pass
+		}
+
+		if requireSignedCommits {
+			status["PLC3006"] = check.Pass
+		}
+
+		for _, logEntry := range logs {
+			lines := strings.Split(logEntry.Message, "\n")
+
+			// Messages written on Windows can end their lines with a carriage return
+			for index, line := range lines {
+				lines[index] = strings.TrimSuffix(line, "\r")
+			}
+
+			subject := lines[0]
+			var failed []string
+
+			if strings.TrimSpace(subject) == "" {
+				failed = append(failed, "PLC3001")
+			}
+
+			if utf8.RuneCountInString(subject) > maxSubjectLength {
+				failed = append(failed, "PLC3002")
+			}
+
+			if len(lines) > 1 && lines[1] != "" {
+				failed = append(failed, "PLC3003")
+			}
+
+			if strings.TrimSpace(logEntry.AuthorEmail) == "" {
+				failed = append(failed, "PLC3005")
+			}
+
+			if requireSignedCommits && logEntry.PGPSignature == "" {
+				failed = append(failed, "PLC3006")
+			}
+
+			// Each commit that fails is reported on its own, so the generic message is not needed
+			for _, code := range failed {
+				delete(status, code)
+
+				messages = append(messages, message.CreateMessage(
+					check.Fail,
+					code,
+					fmt.Sprintf("%s (%s)", codes[code], describeCommit(logEntry, subject)),
+				))
+			}
+		}
+	}
+
+	if len(mainLogs) > 0 {
+		status["PLC3004"] = check.Pass
+
+		for _, logEntry := range mainLogs {
+			if len(logEntry.ParentHashes) > 1 {
+				delete(status, "PLC3004")
+
+				subject := strings.TrimSuffix(strings.Split(logEntry.Message, "\n")[0], "\r")
+
+				messages = append(messages, message.CreateMessage(
+					check.Fail,
+					"PLC3004",
+					fmt.Sprintf("%s (%s)", codes["PLC3004"], describeCommit(logEntry, subject)),
+				))
+			}
+		}
+	}
+
+	for code, checkStatus := range status {
+		messages = append(messages, message.CreateMessage(checkStatus, code, codes[code]))
+	}
+
+	return messages
+}
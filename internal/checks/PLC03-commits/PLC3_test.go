@@ -0,0 +1,280 @@
+package checks
+
+import (
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"internal/check"
+	repo "internal/repositorycontents"
+	"strings"
+	"testing"
+	"time"
+)
+
+type mockCommit struct {
+	email   string
+	merge   bool
+	message string
+	signed  bool
+}
+
+func createLogs(t *testing.T, commits []mockCommit, signKey *openpgp.Entity) []repo.LogEntry {
+	t.Helper()
+
+	var (
+		hashes []plumbing.Hash
+		logs   []repo.LogEntry
+	)
+
+	repository, _ := git.Init(memory.NewStorage(), memfs.New())
+	worktree, _ := repository.Worktree()
+
+	for _, commit := range commits {
+		options := &git.CommitOptions{
+			AllowEmptyCommits: true,
+			Author: &object.Signature{
+				Name:  "Mock Author",
+				Email: commit.email,
+				When:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		}
+
+		if commit.merge && len(hashes) > 1 {
+			options.Parents = []plumbing.Hash{hashes[len(hashes)-1], hashes[0]}
+		}
+
+		if commit.signed {
+			options.SignKey = signKey
+		}
+
+		hash, err := worktree.Commit(commit.message, options)
+		assert.Nil(t, err)
+
+		hashes = append(hashes, hash)
+	}
+
+	log, err := repository.Log(&git.LogOptions{})
+
+	if err == nil {
+		_ = log.ForEach(func(commit *object.Commit) error {
+			logs = append(logs, repo.CreateLogEntry(commit))
+
+			return nil
+		})
+	}
+
+	return logs
+}
+
+func TestPLC3(t *testing.T) {
+	signKey, _ := openpgp.NewEntity("Mock Author", "", "mock@example.com", nil)
+
+	validCommit := mockCommit{email: "mock@example.com", message: "Mock subject\n\nMock body\n"}
+
+	tests := map[string]struct {
+		commits           []mockCommit
+		onMain            bool
+		requireSignatures bool
+		status            map[string]check.Status
+	}{
+		"Repository without commits": {
+			commits: nil,
+			onMain:  true,
+			status: map[string]check.Status{
+				"PLC3001": check.Skip,
+				"PLC3002": check.Skip,
+				"PLC3003": check.Skip,
+				"PLC3004": check.Skip,
+				"PLC3005": check.Skip,
+				"PLC3006": check.Skip,
+			},
+		},
+		"Repository with valid commits": {
+			commits: []mockCommit{validCommit, validCommit},
+			onMain:  true,
+			status: map[string]check.Status{
+				"PLC3001": check.Pass,
+				"PLC3002": check.Pass,
+				"PLC3003": check.Pass,
+				"PLC3004": check.Pass,
+				"PLC3005": check.Pass,
+				"PLC3006": check.Skip,
+			},
+		},
+		"Repository with valid commits without a main branch": {
+			commits: []mockCommit{validCommit},
+			onMain:  false,
+			status: map[string]check.Status{
+				"PLC3001": check.Pass,
+				"PLC3002": check.Pass,
+				"PLC3003": check.Pass,
+				"PLC3004": check.Skip,
+				"PLC3005": check.Pass,
+				"PLC3006": check.Skip,
+			},
+		},
+		"Commit with only a subject line": {
+			commits: []mockCommit{{email: "mock@example.com", message: "Mock subject\n"}},
+			onMain:  true,
+			status: map[string]check.Status{
+				"PLC3001": check.Pass,
+				"PLC3002": check.Pass,
+				"PLC3003": check.Pass,
+				"PLC3004": check.Pass,
+				"PLC3005": check.Pass,
+				"PLC3006": check.Skip,
+			},
+		},
+		"Commit without a subject line": {
+			commits: []mockCommit{{email: "mock@example.com", message: "\nMock body\n"}},
+			onMain:  true,
+			status: map[string]check.Status{
+				"PLC3001": check.Fail,
+				"PLC3002": check.Pass,
+				"PLC3003": check.Fail,
+				"PLC3004": check.Pass,
+				"PLC3005": check.Pass,
+				"PLC3006": check.Skip,
+			},
+		},
+		"Commit with a subject line that is too long": {
+			commits: []mockCommit{{email: "mock@example.com", message: strings.Repeat("x", maxSubjectLength+1)}},
+			onMain:  true,
+			status: map[string]check.Status{
+				"PLC3001": check.Pass,
+				"PLC3002": check.Fail,
+				"PLC3003": check.Pass,
+				"PLC3004": check.Pass,
+				"PLC3005": check.Pass,
+				"PLC3006": check.Skip,
+			},
+		},
+		"Commit with Windows line endings": {
+			commits: []mockCommit{{email: "mock@example.com", message: "Mock subject\r\n\r\nMock body\r\n"}},
+			onMain:  true,
+			status: map[string]check.Status{
+				"PLC3001": check.Pass,
+				"PLC3002": check.Pass,
+				"PLC3003": check.Pass,
+				"PLC3004": check.Pass,
+				"PLC3005": check.Pass,
+				"PLC3006": check.Skip,
+			},
+		},
+		"Commit without a blank line after the subject line": {
+			commits: []mockCommit{{email: "mock@example.com", message: "Mock subject\nMock body\n"}},
+			onMain:  true,
+			status: map[string]check.Status{
+				"PLC3001": check.Pass,
+				"PLC3002": check.Pass,
+				"PLC3003": check.Fail,
+				"PLC3004": check.Pass,
+				"PLC3005": check.Pass,
+				"PLC3006": check.Skip,
+			},
+		},
+		"Commit without an author email address": {
+			commits: []mockCommit{validCommit, {email: "", message: "Mock subject\n"}},
+			onMain:  true,
+			status: map[string]check.Status{
+				"PLC3001": check.Pass,
+				"PLC3002": check.Pass,
+				"PLC3003": check.Pass,
+				"PLC3004": check.Pass,
+				"PLC3005": check.Fail,
+				"PLC3006": check.Skip,
+			},
+		},
+		"Merge commit on the main branch": {
+			commits: []mockCommit{validCommit, validCommit, {email: "mock@example.com", merge: true, message: "Merge branch\n"}},
+			onMain:  true,
+			status: map[string]check.Status{
+				"PLC3001": check.Pass,
+				"PLC3002": check.Pass,
+				"PLC3003": check.Pass,
+				"PLC3004": check.Fail,
+				"PLC3005": check.Pass,
+				"PLC3006": check.Skip,
+			},
+		},
+		"Unsigned commit when signatures are required": {
+			commits:           []mockCommit{validCommit},
+			onMain:            true,
+			requireSignatures: true,
+			status: map[string]check.Status{
+				"PLC3001": check.Pass,
+				"PLC3002": check.Pass,
+				"PLC3003": check.Pass,
+				"PLC3004": check.Pass,
+				"PLC3005": check.Pass,
+				"PLC3006": check.Fail,
+			},
+		},
+		"Signed commit when signatures are required": {
+			commits:           []mockCommit{{email: "mock@example.com", message: "Mock subject\n", signed: true}},
+			onMain:            true,
+			requireSignatures: true,
+			status: map[string]check.Status{
+				"PLC3001": check.Pass,
+				"PLC3002": check.Pass,
+				"PLC3003": check.Pass,
+				"PLC3004": check.Pass,
+				"PLC3005": check.Pass,
+				"PLC3006": check.Pass,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			logs := createLogs(t, test.commits, signKey)
+
+			var mainLogs []repo.LogEntry
+
+			if test.onMain {
+				mainLogs = logs
+			}
+
+			// Act
+			messages := PLC3(logs, mainLogs, test.requireSignatures)
+
+			// Assert
+			for _, message := range messages {
+				assert.Equal(
+					t,
+					test.status[message.Code],
+					message.Status,
+					"%s expected status %v, got %v", message.Code, test.status[message.Code], message.Status,
+				)
+			}
+		})
+	}
+}
+
+func TestPLC3Commits(t *testing.T) {
+	logs := createLogs(t, []mockCommit{
+		{email: "mock@example.com", message: "Mock subject\n\nMock body\n"},
+		{email: "mock@example.com", message: "First mock subject\nMock body\n"},
+		{email: "", message: "Second mock subject\nMock body\n"},
+	}, nil)
+
+	var failed []string
+
+	for _, message := range PLC3(logs, logs, false) {
+		if message.Status == check.Fail {
+			failed = append(failed, message.Code+" "+message.Text())
+		}
+	}
+
+	// The log starts with the most recent commit
+	assert.ElementsMatch(t, []string{
+		"PLC3003 The subject line of a commit message MUST be followed by a blank line (commit `" + logs[0].Hash[:7] + "` \"Second mock subject\")",
+		"PLC3003 The subject line of a commit message MUST be followed by a blank line (commit `" + logs[1].Hash[:7] + "` \"First mock subject\")",
+		"PLC3005 Commits MUST have an author email address (commit `" + logs[0].Hash[:7] + "` \"Second mock subject\")",
+	}, failed)
+}
@@ -2,7 +2,10 @@ package checks
 
 import (
 	"internal/asserts"
+	"internal/fix"
 	"internal/message"
+	"internal/registry"
+	"internal/repofs"
 )
 
 func listCodes() map[string]string {
@@ -12,11 +15,21 @@ func listCodes() map[string]string {
 	}
 }
 
-func PLC4(files map[string]string) []message.Message {
-	var fileCodes = map[string]string{
+func listFiles() map[string]string {
+	return map[string]string{
 		"app/":     "PLC4001",
 		".github/": "PLC4002",
 	}
+}
+
+func init() {
+	registry.Register(registry.CreateFixableCheck("PLC4", "Folders", "", listCodes(), func(checkContext registry.Context) []message.Message {
+		return PLC4(checkContext.Files)
+	}, func(checkContext registry.Context, messages []message.Message) []fix.Change {
+		return fix.CreateFolders(listFiles(), messages)
+	}))
+}
 
-	return asserts.FolderExists(files, fileCodes)
+func PLC4(files repofs.RepoFS) []message.Message {
+	return asserts.FolderExists(files, listFiles())
 }
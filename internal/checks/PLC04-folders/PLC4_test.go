@@ -3,6 +3,10 @@ package checks
 import (
 	"github.com/stretchr/testify/assert"
 	"internal/check"
+	"internal/directorylist"
+	"internal/repofs"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -16,22 +20,22 @@ func TestPLC4(t *testing.T) {
 			status: map[string]check.Status{"PLC4001": check.Fail, "PLC4002": check.Fail},
 		},
 		"app/ folder present, .github/ folder absent": {
-			files:  map[string]string{"app/": "__DIR__"},
+			files:  map[string]string{"app/": ""},
 			status: map[string]check.Status{"PLC4001": check.Pass, "PLC4002": check.Fail},
 		},
 		"app/ folder does absent, .github/ folder present": {
-			files:  map[string]string{".github/": "__DIR__"},
+			files:  map[string]string{".github/": ""},
 			status: map[string]check.Status{"PLC4001": check.Fail, "PLC4002": check.Pass},
 		},
 		"app/ folder present, .github/ folder present": {
-			files:  map[string]string{"app/": "__DIR__", ".github/": "__DIR__"},
+			files:  map[string]string{"app/": "", ".github/": ""},
 			status: map[string]check.Status{"PLC4001": check.Pass, "PLC4002": check.Pass},
 		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			messages := PLC4(test.files)
+			messages := PLC4(repofs.CreateFromMap(test.files))
 
 			for _, message := range messages {
 				assert.Equal(t, test.status[message.Code], message.Status)
@@ -39,3 +43,22 @@ func TestPLC4(t *testing.T) {
 		})
 	}
 }
+
+func TestPLC4Symlink(t *testing.T) {
+	root := t.TempDir()
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "src"), 0o755))
+	assert.NoError(t, os.Symlink(filepath.Join(root, "src"), filepath.Join(root, "app")))
+
+	files, err := repofs.CreateFromDirectory(root, directorylist.Options{})
+
+	assert.NoError(t, err)
+
+	for _, message := range PLC4(files) {
+		if message.Code == "PLC4001" {
+			// A symbolic link is not followed, so it is not reported as missing
+			assert.Equal(t, check.Error, message.Status)
+			assert.Equal(t, "`app/` is a symbolic link, which is not followed", message.Reason)
+		}
+	}
+}
@@ -2,7 +2,10 @@ package checks
 
 import (
 	"internal/asserts"
+	"internal/fix"
 	"internal/message"
+	"internal/registry"
+	"internal/repofs"
 )
 
 func listCodes() map[string]string {
@@ -19,8 +22,8 @@ func listCodes() map[string]string {
 	}
 }
 
-func PLC5(files map[string]string) []message.Message {
-	fileCodes := map[string]string{
+func listFiles() map[string]string {
+	return map[string]string{
 		".gitignore":     "PLC5001",
 		".gitlab-ci.yml": "PLC5002",
 		".mdlrc":         "PLC5003",
@@ -31,6 +34,16 @@ func PLC5(files map[string]string) []message.Message {
 		"README.md":      "PLC5008",
 		"renovate.json":  "PLC5009",
 	}
+}
+
+func init() {
+	registry.Register(registry.CreateFixableCheck("PLC5", "Files", "", listCodes(), func(checkContext registry.Context) []message.Message {
+		return PLC5(checkContext.Files)
+	}, func(checkContext registry.Context, messages []message.Message) []fix.Change {
+		return fix.CopyFromSkeleton(checkContext.Files, checkContext.Skeleton, listFiles(), messages)
+	}))
+}
 
-	return asserts.FileExists(files, fileCodes)
+func PLC5(files repofs.RepoFS) []message.Message {
+	return asserts.FileExists(files, listFiles())
 }
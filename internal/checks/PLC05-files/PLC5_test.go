@@ -3,6 +3,7 @@ package checks
 import (
 	"github.com/stretchr/testify/assert"
 	"internal/check"
+	"internal/repofs"
 	"strconv"
 	"testing"
 )
@@ -91,7 +92,7 @@ func TestPLC5(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			messages := PLC5(test.files)
+			messages := PLC5(repofs.CreateFromMap(test.files))
 
 			for _, message := range messages {
 				assert.Equal(t, test.status[message.Code], message.Status)
@@ -0,0 +1,137 @@
+package checks
+
+import (
+	"fmt"
+	"internal/check"
+	"internal/message"
+	"internal/registry"
+	"internal/repofs"
+	"slices"
+	"strings"
+)
+
+const targetFile = ".gitignore"
+
+func listCodes() map[string]string {
+	return map[string]string{
+		"PLC6001": "The `.gitignore` file MUST contain all entries from the `.gitignore` file in the skeleton repository",
+		"PLC6002": "The `.gitignore` file MAY contain entries in addition to those in the skeleton repository",
+	}
+}
+
+func init() {
+	registry.Register(registry.CreateCheck("PLC6", ".gitignore file", targetFile, listCodes(), func(checkContext registry.Context) []message.Message {
+		return PLC6(checkContext.Files, checkContext.Skeleton)
+	}))
+}
+
+// pattern is an entry in a `.gitignore` file, with the line it is on
+type pattern struct {
+	line int
+	text string
+}
+
+// trimTrailingSpaces removes the trailing spaces git ignores, those that are
+// not escaped with a backslash.
+func trimTrailingSpaces(line string) string {
+	for strings.HasSuffix(line, " ") {
+		rest := line[:len(line)-1]
+
+		// An odd number of backslashes escapes the space, an even number escapes each other
+		if (len(rest)-len(strings.TrimRight(rest, "\\")))%2 == 1 {
+			break
+		}
+
+		line = rest
+	}
+
+	return line
+}
+
+// getPatterns returns the entries in the given `.gitignore` content, in the
+// same form git reads them: leading whitespace is part of a pattern, trailing
+// spaces are not, unless they are escaped.
+func getPatterns(content string) []pattern {
+	var patterns []pattern
+
+	for index, line := range strings.Split(content, "\n") {
+		line = trimTrailingSpaces(strings.TrimSuffix(line, "\r"))
+
+		if line != "" && !strings.HasPrefix(line, "#") && !containsPattern(patterns, line) {
+			patterns = append(patterns, pattern{line: index + 1, text: line})
+		}
+	}
+
+	return patterns
+}
+
+func containsPattern(patterns []pattern, text string) bool {
+	return slices.ContainsFunc(patterns, func(candidate pattern) bool {
+		return candidate.text == text
+	})
+}
+
+func PLC6(files repofs.RepoFS, repo repofs.RepoFS) []message.Message {
+	var (
+		messages []message.Message
+		ok       bool
+	)
+
+	reasons := map[string]string{}
+	status := map[string]check.Status{}
+	codes := listCodes()
+
+	for code := range codes {
+		status[code] = check.Skip
+	}
+
+	if _, ok = files.Stat(targetFile); ok {
+		if _, repoFileExists := repo.Stat(targetFile); !repoFileExists {
+			reasons["PLC6001"] = fmt.Sprintf("The required `%s` file is missing from the skeleton repository", targetFile)
+			status["PLC6001"] = check.Error
+		} else if skeletonContent, err := repo.ReadFile(targetFile); err != nil {
+			reasons["PLC6001"] = fmt.Sprintf("The `%s` file in the skeleton repository could not be read: %v", targetFile, err)
+			status["PLC6001"] = check.Error
+		} else if subjectContent, err := files.ReadFile(targetFile); err != nil {
+			reasons["PLC6001"] = fmt.Sprintf("The `%s` file could not be read: %v", targetFile, err)
+			status["PLC6001"] = check.Error
+		} else {
+			subjectPatterns := getPatterns(subjectContent)
+			skeletonPatterns := getPatterns(skeletonContent)
+
+			status["PLC6001"] = check.Pass
+
+			for _, skeletonPattern := range skeletonPatterns {
+				if !containsPattern(subjectPatterns, skeletonPattern.text) {
+					// Each missing pattern is reported on its own, at its line in the skeleton
+					delete(status, "PLC6001")
+
+					messages = append(messages, message.CreateLocatedMessage(
+						check.Fail,
+						"PLC6001",
+						targetFile,
+						skeletonPattern.line,
+						0,
+						fmt.Sprintf("The `.gitignore` file MUST contain the `%s` entry from the `.gitignore` file in the skeleton repository", skeletonPattern.text),
+					))
+				}
+			}
+
+			for _, subjectPattern := range subjectPatterns {
+				if !containsPattern(skeletonPatterns, subjectPattern.text) {
+					status["PLC6002"] = check.Pass
+				}
+			}
+		}
+	}
+
+	for code, checkStatus := range status {
+		if checkStatus == check.Error {
+			messages = append(messages, message.CreateErrorMessage(code, codes[code], reasons[code]))
+		} else {
+			messages = append(messages, message.CreateMessage(checkStatus, code, codes[code]))
+		}
+	}
+
+	return messages
+}
@@ -0,0 +1,112 @@
+package checks
+
+import (
+	"github.com/stretchr/testify/assert"
+	"internal/check"
+	"internal/repofs"
+	"testing"
+)
+
+func TestPLC6(t *testing.T) {
+	mockSkeleton := map[string]string{targetFile: "# Mock comment\n/foo\n\nbar/\n"}
+
+	tests := map[string]struct {
+		files    map[string]string
+		repo     map[string]string
+		status   map[string]check.Status
+		messages int
+	}{
+		targetFile + " file absent": {
+			files:    nil,
+			repo:     mockSkeleton,
+			status:   map[string]check.Status{"PLC6001": check.Skip, "PLC6002": check.Skip},
+			messages: 2,
+		},
+		targetFile + " file absent from skeleton repo": {
+			files:    map[string]string{targetFile: "/foo\n"},
+			repo:     nil,
+			status:   map[string]check.Status{"PLC6001": check.Error, "PLC6002": check.Skip},
+			messages: 2,
+		},
+		targetFile + " file identical to skeleton": {
+			files:    mockSkeleton,
+			repo:     mockSkeleton,
+			status:   map[string]check.Status{"PLC6001": check.Pass, "PLC6002": check.Skip},
+			messages: 2,
+		},
+		targetFile + " file with same entries in a different order and without comments": {
+			files:    map[string]string{targetFile: "bar/\r\n/foo  \r\n"},
+			repo:     mockSkeleton,
+			status:   map[string]check.Status{"PLC6001": check.Pass, "PLC6002": check.Skip},
+			messages: 2,
+		},
+		targetFile + " file with an entry with leading whitespace": {
+			files:    map[string]string{targetFile: "bar/\n  /foo\n"},
+			repo:     mockSkeleton,
+			status:   map[string]check.Status{"PLC6001": check.Fail, "PLC6002": check.Pass},
+			messages: 2,
+		},
+		targetFile + " file with an entry with an escaped trailing space": {
+			files:    map[string]string{targetFile: "bar/\n/foo\\ \n"},
+			repo:     mockSkeleton,
+			status:   map[string]check.Status{"PLC6001": check.Fail, "PLC6002": check.Pass},
+			messages: 2,
+		},
+		targetFile + " file with additional entries": {
+			files:    map[string]string{targetFile: "/foo\nbar/\n*.log\n"},
+			repo:     mockSkeleton,
+			status:   map[string]check.Status{"PLC6001": check.Pass, "PLC6002": check.Pass},
+			messages: 2,
+		},
+		targetFile + " file missing one entry": {
+			files:    map[string]string{targetFile: "/foo\n*.log\n"},
+			repo:     mockSkeleton,
+			status:   map[string]check.Status{"PLC6001": check.Fail, "PLC6002": check.Pass},
+			messages: 2,
+		},
+		targetFile + " file missing all entries": {
+			files:    map[string]string{targetFile: ""},
+			repo:     mockSkeleton,
+			status:   map[string]check.Status{"PLC6001": check.Fail, "PLC6002": check.Skip},
+			messages: 3,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			messages := PLC6(repofs.CreateFromMap(test.files), repofs.CreateFromMap(test.repo))
+
+			assert.Len(t, messages, test.messages)
+
+			for _, message := range messages {
+				assert.Equal(t, test.status[message.Code], message.Status, "%s expected status %v, got %v", message.Code, test.status[message.Code], message.Status)
+			}
+		})
+	}
+}
+
+func TestPLC6Location(t *testing.T) {
+	messages := PLC6(
+		repofs.CreateFromMap(map[string]string{targetFile: "/foo\n"}),
+		repofs.CreateFromMap(map[string]string{targetFile: "# Mock comment\n/foo\n\nbar/\n"}),
+	)
+
+	found := false
+
+	for _, message := range messages {
+		if message.Code == "PLC6001" {
+			found = true
+
+			assert.Equal(t, targetFile, message.Path)
+			assert.Equal(t, 4, message.Line)
+		}
+	}
+
+	assert.True(t, found, "PLC6001 expected a message for the missing entry")
+}
+
+func TestGetPatterns(t *testing.T) {
+	expected := []pattern{{line: 1, text: "/foo"}, {line: 2, text: "  bar"}, {line: 3, text: "baz\\ "}, {line: 4, text: "qux\\\\"}}
+
+	assert.Equal(t, expected, getPatterns("/foo \r\n  bar\nbaz\\ \nqux\\\\ \n# comment\n   \n"))
+}
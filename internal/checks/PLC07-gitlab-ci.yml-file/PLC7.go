@@ -0,0 +1,245 @@
+package checks
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"internal/check"
+	"internal/message"
+	"internal/registry"
+	"internal/repofs"
+	"slices"
+	"sort"
+	"strings"
+)
+
+const targetFile = ".gitlab-ci.yml"
+
+// Top-level keys that are global keywords rather than job names
+var globalKeywords = []string{
+	"after_script",
+	"before_script",
+	"cache",
+	"default",
+	"image",
+	"include",
+	"services",
+	"stages",
+	"variables",
+	"workflow",
+}
+
+func listCodes() map[string]string {
+	return map[string]string{
+		"PLC7001": "The `.gitlab-ci.yml` file MUST be valid YAML",
+		"PLC7002": "The `.gitlab-ci.yml` file MUST include the shared CI templates from the skeleton repository",
+		"PLC7003": "The `.gitlab-ci.yml` file MUST declare the same stages as the `.gitlab-ci.yml` file in the skeleton repository",
+		"PLC7004": "The `.gitlab-ci.yml` file MUST declare the same jobs as the `.gitlab-ci.yml` file in the skeleton repository",
+		"PLC7005": "The `.gitlab-ci.yml` file MUST NOT override the build image",
+	}
+}
+
+func init() {
+	registry.Register(registry.CreateCheck("PLC7", ".gitlab-ci.yml file", targetFile, listCodes(), func(checkContext registry.Context) []message.Message {
+		return PLC7(checkContext.Files, checkContext.Skeleton)
+	}))
+}
+
+func getIncludes(config map[string]interface{}) []string {
+	var includes []string
+
+	var entries []interface{}
+
+	switch include := config["include"].(type) {
+	case []interface{}:
+		entries = include
+	case nil:
+		entries = nil
+	default:
+		entries = []interface{}{include}
+	}
+
+	for _, entry := range entries {
+		switch entry := entry.(type) {
+		case string:
+			includes = append(includes, fmt.Sprintf("local `%s`", entry))
+		case map[string]interface{}:
+			if project, ok := entry["project"]; ok {
+				var files []interface{}
+
+				switch file := entry["file"].(type) {
+				case []interface{}:
+					files = file
+				default:
+					files = []interface{}{file}
+				}
+
+				for _, file := range files {
+					includes = append(includes, fmt.Sprintf("project `%v` file `%v`", project, file))
+				}
+			} else {
+				for _, key := range []string{"component", "local", "remote", "template"} {
+					if value, ok := entry[key]; ok {
+						includes = append(includes, fmt.Sprintf("%s `%v`", key, value))
+					}
+				}
+			}
+		}
+	}
+
+	return includes
+}
+
+func getJobs(config map[string]interface{}) []string {
+	var jobs []string
+
+	for key := range config {
+		if !slices.Contains(globalKeywords, key) && !strings.HasPrefix(key, ".") {
+			jobs = append(jobs, key)
+		}
+	}
+
+	sort.Strings(jobs)
+
+	return jobs
+}
+
+func getImages(config map[string]interface{}) map[string]string {
+	images := map[string]string{}
+
+	if image, ok := config["image"]; ok {
+		images["image"] = fmt.Sprintf("%v", image)
+	}
+
+	for key, value := range config {
+		if job, ok := value.(map[string]interface{}); ok && key != "variables" && key != "workflow" {
+			if image, ok := job["image"]; ok {
+				images[key+".image"] = fmt.Sprintf("%v", image)
+			}
+		}
+	}
+
+	return images
+}
+
+func getStages(config map[string]interface{}) []string {
+	var stages []string
+
+	if list, ok := config["stages"].([]interface{}); ok {
+		for _, stage := range list {
+			stages = append(stages, fmt.Sprintf("%v", stage))
+		}
+	}
+
+	return stages
+}
+
+func parse(content string) (map[string]interface{}, error) {
+	config := map[string]interface{}{}
+
+	err := yaml.Unmarshal([]byte(content), &config)
+
+	return config, err
+}
+
+func PLC7(files repofs.RepoFS, repo repofs.RepoFS) []message.Message {
+	var (
+		messages []message.Message
+		ok       bool
+	)
+
+	reasons := map[string]string{}
+	status := map[string]check.Status{}
+	codes := listCodes()
+
+	for code := range codes {
+		status[code] = check.Skip
+	}
+
+	fail := func(code string, text string) {
+		delete(status, code)
+		messages = append(messages, message.CreateMessage(check.Fail, code, text))
+	}
+
+	if _, ok = files.Stat(targetFile); ok {
+		if _, repoFileExists := repo.Stat(targetFile); !repoFileExists {
+			reasons["PLC7001"] = fmt.Sprintf("The required `%s` file is missing from the skeleton repository", targetFile)
+			status["PLC7001"] = check.Error
+		} else if skeletonContent, err := repo.ReadFile(targetFile); err != nil {
+			reasons["PLC7001"] = fmt.Sprintf("The `%s` file in the skeleton repository could not be read: %v", targetFile, err)
+			status["PLC7001"] = check.Error
+		} else if subjectContent, err := files.ReadFile(targetFile); err != nil {
+			reasons["PLC7001"] = fmt.Sprintf("The `%s` file could not be read: %v", targetFile, err)
+			status["PLC7001"] = check.Error
+		} else if skeletonConfig, err := parse(skeletonContent); err != nil {
+			reasons["PLC7001"] = fmt.Sprintf("The `%s` file in the skeleton repository is not valid YAML: %v", targetFile, err)
+			status["PLC7001"] = check.Error
+		} else if subjectConfig, err := parse(subjectContent); err != nil {
+			fail("PLC7001", fmt.Sprintf("The `%s` file MUST be valid YAML: %v", targetFile, err))
+		} else {
+			for code := range codes {
+				status[code] = check.Pass
+			}
+
+			subjectIncludes := getIncludes(subjectConfig)
+
+			for _, include := range getIncludes(skeletonConfig) {
+				if !slices.Contains(subjectIncludes, include) {
+					fail("PLC7002", fmt.Sprintf("The `include` key MUST include %s", include))
+				}
+			}
+
+			subjectStages := getStages(subjectConfig)
+			skeletonStages := getStages(skeletonConfig)
+
+			if !slices.Equal(subjectStages, skeletonStages) {
+				fail("PLC7003", fmt.Sprintf(
+					"The `stages` key MUST be [%s], found [%s]",
+					strings.Join(skeletonStages, ", "),
+					strings.Join(subjectStages, ", "),
+				))
+			}
+
+			subjectJobs := getJobs(subjectConfig)
+			skeletonJobs := getJobs(skeletonConfig)
+
+			for _, job := range skeletonJobs {
+				if !slices.Contains(subjectJobs, job) {
+					fail("PLC7004", fmt.Sprintf("The `%s` job MUST be declared", job))
+				}
+			}
+
+			for _, job := range subjectJobs {
+				if !slices.Contains(skeletonJobs, job) {
+					fail("PLC7004", fmt.Sprintf("The `%s` job MUST NOT be declared", job))
+				}
+			}
+
+			skeletonImages := getImages(skeletonConfig)
+			subjectImages := getImages(subjectConfig)
+
+			var imagePaths []string
+
+			for path := range subjectImages {
+				imagePaths = append(imagePaths, path)
+			}
+
+			sort.Strings(imagePaths)
+
+			for _, path := range imagePaths {
+				if skeletonImage, found := skeletonImages[path]; !found || skeletonImage != subjectImages[path] {
+					fail("PLC7005", fmt.Sprintf("The `%s` key MUST NOT override the build image", path))
+				}
+			}
+		}
+	}
+
+	for code, checkStatus := range status {
+		if checkStatus == check.Error {
+			messages = append(messages, message.CreateErrorMessage(code, codes[code], reasons[code]))
+		} else {
+			messages = append(messages, message.CreateMessage(checkStatus, code, codes[code]))
+		}
+	}
+
+	return messages
+}
@@ -0,0 +1,150 @@
+package checks
+
+import (
+	"github.com/stretchr/testify/assert"
+	"internal/check"
+	"internal/repofs"
+	"testing"
+)
+
+const mockSkeleton = `---
+include:
+  - project: pipeline-components/org/config
+    file:
+      - pipeline.yml
+      - release.yml
+
+stages:
+  - linting
+  - build
+
+lint:
+  stage: linting
+
+build:
+  stage: build
+`
+
+func TestPLC7(t *testing.T) {
+	tests := map[string]struct {
+		files    map[string]string
+		repo     map[string]string
+		status   map[string]check.Status
+		messages []string
+	}{
+		targetFile + " file absent": {
+			files: nil,
+			repo:  map[string]string{targetFile: mockSkeleton},
+			status: map[string]check.Status{
+				"PLC7001": check.Skip,
+				"PLC7002": check.Skip,
+				"PLC7003": check.Skip,
+				"PLC7004": check.Skip,
+				"PLC7005": check.Skip,
+			},
+		},
+		targetFile + " file absent from skeleton repo": {
+			files: map[string]string{targetFile: mockSkeleton},
+			repo:  nil,
+			status: map[string]check.Status{
+				"PLC7001": check.Error,
+				"PLC7002": check.Skip,
+				"PLC7003": check.Skip,
+				"PLC7004": check.Skip,
+				"PLC7005": check.Skip,
+			},
+		},
+		targetFile + " file is not valid YAML": {
+			files: map[string]string{targetFile: "foo: [bar"},
+			repo:  map[string]string{targetFile: mockSkeleton},
+			status: map[string]check.Status{
+				"PLC7001": check.Fail,
+				"PLC7002": check.Skip,
+				"PLC7003": check.Skip,
+				"PLC7004": check.Skip,
+				"PLC7005": check.Skip,
+			},
+		},
+		targetFile + " file identical to skeleton": {
+			files: map[string]string{targetFile: mockSkeleton},
+			repo:  map[string]string{targetFile: mockSkeleton},
+			status: map[string]check.Status{
+				"PLC7001": check.Pass,
+				"PLC7002": check.Pass,
+				"PLC7003": check.Pass,
+				"PLC7004": check.Pass,
+				"PLC7005": check.Pass,
+			},
+		},
+		targetFile + " file with a single include, different stages and jobs": {
+			files: map[string]string{targetFile: `---
+include:
+  project: pipeline-components/org/config
+  file: pipeline.yml
+
+stages:
+  - build
+
+build:
+  stage: build
+
+test:
+  stage: build
+`},
+			repo: map[string]string{targetFile: mockSkeleton},
+			status: map[string]check.Status{
+				"PLC7001": check.Pass,
+				"PLC7002": check.Fail,
+				"PLC7003": check.Fail,
+				"PLC7004": check.Fail,
+				"PLC7005": check.Pass,
+			},
+			messages: []string{
+				"The `include` key MUST include project `pipeline-components/org/config` file `release.yml`",
+				"The `stages` key MUST be [linting, build], found [build]",
+				"The `lint` job MUST be declared",
+				"The `test` job MUST NOT be declared",
+			},
+		},
+		targetFile + " file overriding the build image": {
+			files: map[string]string{targetFile: mockSkeleton + `
+default:
+  image: alpine
+
+.hidden:
+  image: alpine
+` + "\n" + `image: alpine`},
+			repo: map[string]string{targetFile: mockSkeleton},
+			status: map[string]check.Status{
+				"PLC7001": check.Pass,
+				"PLC7002": check.Pass,
+				"PLC7003": check.Pass,
+				"PLC7004": check.Pass,
+				"PLC7005": check.Fail,
+			},
+			messages: []string{
+				"The `.hidden.image` key MUST NOT override the build image",
+				"The `default.image` key MUST NOT override the build image",
+				"The `image` key MUST NOT override the build image",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			messages := PLC7(repofs.CreateFromMap(test.files), repofs.CreateFromMap(test.repo))
+
+			var failures []string
+
+			for _, message := range messages {
+				assert.Equal(t, test.status[message.Code], message.Status, "%s expected status %v, got %v", message.Code, test.status[message.Code], message.Status)
+
+				if message.Status == check.Fail && message.Code != "PLC7001" {
+					failures = append(failures, message.Message)
+				}
+			}
+
+			assert.Equal(t, test.messages, failures)
+		})
+	}
+}
@@ -2,7 +2,10 @@ package checks
 
 import (
 	"internal/asserts"
+	"internal/fix"
 	"internal/message"
+	"internal/registry"
+	"internal/repofs"
 )
 
 func listCodes() map[string]string {
@@ -11,10 +14,20 @@ func listCodes() map[string]string {
 	}
 }
 
-func PLC8(files map[string]string, repo map[string]string) []message.Message {
-	var fileCodes = map[string]string{
+func listFiles() map[string]string {
+	return map[string]string{
 		".mdlrc": "PLC8001",
 	}
+}
+
+func init() {
+	registry.Register(registry.CreateFixableCheck("PLC8", ".mdlrc file", ".mdlrc", listCodes(), func(checkContext registry.Context) []message.Message {
+		return PLC8(checkContext.Files, checkContext.Skeleton)
+	}, func(checkContext registry.Context, messages []message.Message) []fix.Change {
+		return fix.CopyFromSkeleton(checkContext.Files, checkContext.Skeleton, listFiles(), messages)
+	}))
+}
 
-	return asserts.CompareFiles(files, repo, fileCodes)
+func PLC8(files repofs.RepoFS, repo repofs.RepoFS) []message.Message {
+	return asserts.CompareFiles(files, repo, listFiles())
 }
@@ -3,6 +3,7 @@ package checks
 import (
 	"github.com/stretchr/testify/assert"
 	"internal/check"
+	"internal/repofs"
 	"testing"
 )
 
@@ -38,7 +39,7 @@ func TestPLC8(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			messages := PLC8(test.files, test.repo)
+			messages := PLC8(repofs.CreateFromMap(test.files), repofs.CreateFromMap(test.repo))
 
 			for _, message := range messages {
 				assert.Equal(t, test.status[message.Code], message.Status)
@@ -46,3 +47,43 @@ func TestPLC8(t *testing.T) {
 		})
 	}
 }
+
+func TestPLC8Diff(t *testing.T) {
+	targetFile := ".mdlrc"
+
+	tests := map[string]struct {
+		diff    string
+		files   map[string]string
+		message string
+		repo    map[string]string
+	}{
+		targetFile + " file identical": {
+			diff:    "",
+			files:   map[string]string{targetFile: "line 1\n"},
+			message: "The `.mdlrc` file MUST be identical to `.mdlrc` file in the skeleton repository",
+			repo:    map[string]string{targetFile: "line 1\n"},
+		},
+		targetFile + " file with different content": {
+			diff:    "--- component/.mdlrc\n+++ skeleton/.mdlrc\n@@ -1 +1 @@\n-line 1\n+line 2\n",
+			files:   map[string]string{targetFile: "line 1\n"},
+			message: "The `.mdlrc` file MUST be identical to `.mdlrc` file in the skeleton repository",
+			repo:    map[string]string{targetFile: "line 2\n"},
+		},
+		targetFile + " file with different whitespace": {
+			diff:    "--- component/.mdlrc\n+++ skeleton/.mdlrc\n@@ -1 +1 @@\n-line 1 \n+line 1\n",
+			files:   map[string]string{targetFile: "line 1 \n"},
+			message: "The `.mdlrc` file MUST be identical to `.mdlrc` file in the skeleton repository (only whitespace differs)",
+			repo:    map[string]string{targetFile: "line 1\n"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			messages := PLC8(repofs.CreateFromMap(test.files), repofs.CreateFromMap(test.repo))
+
+			assert.Len(t, messages, 1)
+			assert.Equal(t, test.diff, messages[0].Diff)
+			assert.Equal(t, test.message, messages[0].Message)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package checks
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"internal/check"
+	"internal/message"
+	"internal/registry"
+	"internal/repofs"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const targetFile = "action.yml"
+
+var errorLinePattern = regexp.MustCompile(`^line (\d+): `)
+
+type action struct {
+	Author      string      `yaml:"author"`
+	Branding    interface{} `yaml:"branding"`
+	Description string      `yaml:"description"`
+	Name        string      `yaml:"name"`
+	Runs        struct {
+		Image string `yaml:"image"`
+		Using string `yaml:"using"`
+	} `yaml:"runs"`
+}
+
+func listCodes() map[string]string {
+	return map[string]string{
+		"PLC10001": "The `action.yml` file MUST be valid YAML",
+		"PLC10002": "The `action.yml` file MUST contain a `name`",
+		"PLC10003": "The `action.yml` file MUST contain a `description`",
+		"PLC10004": "The `action.yml` file MUST contain an `author`",
+		"PLC10005": "The `action.yml` file MUST contain `branding`",
+		"PLC10006": "The `runs.using` in the `action.yml` file MUST be `docker`",
+		"PLC10007": "The `runs.image` in the `action.yml` file MUST point to the `Dockerfile` in the repository",
+		"PLC10008": "The `name` in the `action.yml` file MUST match the component folder name",
+	}
+}
+
+func init() {
+	registry.Register(registry.CreateCheck("PLC10", "action.yml file", targetFile, listCodes(), func(checkContext registry.Context) []message.Message {
+		return PLC10(checkContext.ComponentName, checkContext.Files)
+	}))
+}
+
+// describeError returns the line of the given YAML error, and what the error
+// is without it. The line is zero when it is not known.
+func describeError(err error) (int, string) {
+	var typeError *yaml.TypeError
+
+	line := 0
+	description := strings.TrimPrefix(err.Error(), "yaml: ")
+
+	// Only the first field that could not be read is reported
+	if errors.As(err, &typeError) && len(typeError.Errors) > 0 {
+		description = typeError.Errors[0]
+	}
+
+	if matches := errorLinePattern.FindStringSubmatch(description); matches != nil {
+		line, _ = strconv.Atoi(matches[1])
+		description = strings.TrimPrefix(description, matches[0])
+	}
+
+	return line, description
+}
+
+// findColumn returns the column of the first value on the given line, as that
+// is what could not be read, or zero when there is none. Keys are skipped.
+func findColumn(node *yaml.Node, line int) int {
+	if node.Line == line && node.Kind != yaml.DocumentNode && node.Kind != yaml.MappingNode {
+		return node.Column
+	}
+
+	for index, child := range node.Content {
+		if node.Kind == yaml.MappingNode && index%2 == 0 {
+			continue
+		}
+
+		if column := findColumn(child, line); column > 0 {
+			return column
+		}
+	}
+
+	return 0
+}
+
+// parse reads the metadata in the given content. When that fails, the line
+// and column of the problem are returned as well, as far as they are known.
+func parse(content string, metadata *action) (int, int, error) {
+	var document yaml.Node
+
+	if err := yaml.Unmarshal([]byte(content), &document); err != nil {
+		line, _ := describeError(err)
+
+		return line, 0, err
+	}
+
+	if err := document.Decode(metadata); err != nil {
+		line, _ := describeError(err)
+
+		return line, findColumn(&document, line), err
+	}
+
+	return 0, 0, nil
+}
+
+func PLC10(componentName string, files repofs.RepoFS) []message.Message {
+	var (
+		messages []message.Message
+		ok       bool
+	)
+
+	reasons := map[string]string{}
+	status := map[string]check.Status{}
+	codes := listCodes()
+
+	for code := range codes {
+		status[code] = check.Skip
+	}
+
+	if _, ok = files.Stat(targetFile); ok {
+		var metadata action
+
+		content, err := files.ReadFile(targetFile)
+
+		if err != nil {
+			reasons["PLC10001"] = fmt.Sprintf("The `%s` file could not be read: %v", targetFile, err)
+			status["PLC10001"] = check.Error
+		} else if line, column, err := parse(content, &metadata); err != nil {
+			_, description := describeError(err)
+
+			failure := message.CreateLocatedMessage(check.Fail, "PLC10001", "", line, column, codes["PLC10001"])
+			failure.Reason = description
+
+			delete(status, "PLC10001")
+			messages = append(messages, failure)
+		} else {
+			for code := range codes {
+				status[code] = check.Fail
+			}
+
+			status["PLC10001"] = check.Pass
+
+			if strings.TrimSpace(metadata.Name) != "" {
+				status["PLC10002"] = check.Pass
+
+				if metadata.Name == componentName {
+					status["PLC10008"] = check.Pass
+				}
+			} else {
+				status["PLC10008"] = check.Skip
+			}
+
+			if strings.TrimSpace(metadata.Description) != "" {
+				status["PLC10003"] = check.Pass
+			}
+
+			if strings.TrimSpace(metadata.Author) != "" {
+				status["PLC10004"] = check.Pass
+			}
+
+			if metadata.Branding != nil {
+				status["PLC10005"] = check.Pass
+			}
+
+			if metadata.Runs.Using == "docker" {
+				status["PLC10006"] = check.Pass
+			}
+
+			if metadata.Runs.Image == "Dockerfile" || metadata.Runs.Image == "./Dockerfile" {
+				status["PLC10007"] = check.Pass
+			}
+		}
+	}
+
+	for code, checkStatus := range status {
+		if checkStatus == check.Error {
+			messages = append(messages, message.CreateErrorMessage(code, codes[code], reasons[code]))
+		} else {
+			messages = append(messages, message.CreateMessage(checkStatus, code, codes[code]))
+		}
+	}
+
+	return messages
+}
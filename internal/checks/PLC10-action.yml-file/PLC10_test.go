@@ -0,0 +1,190 @@
+package checks
+
+import (
+	"github.com/stretchr/testify/assert"
+	"internal/check"
+	"internal/repofs"
+	"testing"
+)
+
+const mockAction = `---
+name: mock
+description: Mock description
+author: Mock Author
+branding:
+  color: gray-dark
+  icon: code
+runs:
+  using: docker
+  image: Dockerfile
+`
+
+func TestPLC10(t *testing.T) {
+	tests := map[string]struct {
+		componentName string
+		files         map[string]string
+		status        map[string]check.Status
+	}{
+		targetFile + " file absent": {
+			componentName: "mock",
+			files:         nil,
+			status: map[string]check.Status{
+				"PLC10001": check.Skip,
+				"PLC10002": check.Skip,
+				"PLC10003": check.Skip,
+				"PLC10004": check.Skip,
+				"PLC10005": check.Skip,
+				"PLC10006": check.Skip,
+				"PLC10007": check.Skip,
+				"PLC10008": check.Skip,
+			},
+		},
+		targetFile + " file is not valid YAML": {
+			componentName: "mock",
+			files:         map[string]string{targetFile: "name: [mock"},
+			status: map[string]check.Status{
+				"PLC10001": check.Fail,
+				"PLC10002": check.Skip,
+				"PLC10003": check.Skip,
+				"PLC10004": check.Skip,
+				"PLC10005": check.Skip,
+				"PLC10006": check.Skip,
+				"PLC10007": check.Skip,
+				"PLC10008": check.Skip,
+			},
+		},
+		targetFile + " file is empty": {
+			componentName: "mock",
+			files:         map[string]string{targetFile: ""},
+			status: map[string]check.Status{
+				"PLC10001": check.Pass,
+				"PLC10002": check.Fail,
+				"PLC10003": check.Fail,
+				"PLC10004": check.Fail,
+				"PLC10005": check.Fail,
+				"PLC10006": check.Fail,
+				"PLC10007": check.Fail,
+				"PLC10008": check.Skip,
+			},
+		},
+		targetFile + " file with valid metadata": {
+			componentName: "mock",
+			files:         map[string]string{targetFile: mockAction},
+			status: map[string]check.Status{
+				"PLC10001": check.Pass,
+				"PLC10002": check.Pass,
+				"PLC10003": check.Pass,
+				"PLC10004": check.Pass,
+				"PLC10005": check.Pass,
+				"PLC10006": check.Pass,
+				"PLC10007": check.Pass,
+				"PLC10008": check.Pass,
+			},
+		},
+		targetFile + " file with name not matching the component folder": {
+			componentName: "other",
+			files:         map[string]string{targetFile: mockAction},
+			status: map[string]check.Status{
+				"PLC10001": check.Pass,
+				"PLC10002": check.Pass,
+				"PLC10003": check.Pass,
+				"PLC10004": check.Pass,
+				"PLC10005": check.Pass,
+				"PLC10006": check.Pass,
+				"PLC10007": check.Pass,
+				"PLC10008": check.Fail,
+			},
+		},
+		targetFile + " file with an image not pointing to the repository Dockerfile": {
+			componentName: "mock",
+			files: map[string]string{targetFile: `---
+name: mock
+description: Mock description
+author: Mock Author
+branding:
+  color: gray-dark
+runs:
+  using: docker
+  image: docker://alpine
+`},
+			status: map[string]check.Status{
+				"PLC10001": check.Pass,
+				"PLC10002": check.Pass,
+				"PLC10003": check.Pass,
+				"PLC10004": check.Pass,
+				"PLC10005": check.Pass,
+				"PLC10006": check.Pass,
+				"PLC10007": check.Fail,
+				"PLC10008": check.Pass,
+			},
+		},
+		targetFile + " file with a javascript action": {
+			componentName: "mock",
+			files: map[string]string{targetFile: `---
+name: mock
+description: Mock description
+author: Mock Author
+branding:
+  color: gray-dark
+runs:
+  using: node20
+  main: index.js
+`},
+			status: map[string]check.Status{
+				"PLC10001": check.Pass,
+				"PLC10002": check.Pass,
+				"PLC10003": check.Pass,
+				"PLC10004": check.Pass,
+				"PLC10005": check.Pass,
+				"PLC10006": check.Fail,
+				"PLC10007": check.Fail,
+				"PLC10008": check.Pass,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			messages := PLC10(test.componentName, repofs.CreateFromMap(test.files))
+
+			for _, message := range messages {
+				assert.Equal(t, test.status[message.Code], message.Status, "%s expected status %v, got %v", message.Code, test.status[message.Code], message.Status)
+			}
+		})
+	}
+}
+
+func TestPLC10Location(t *testing.T) {
+	tests := map[string]struct {
+		column  int
+		content string
+		line    int
+		reason  string
+	}{
+		"Syntax error": {
+			content: "name: mock\ndescription: mock: description\n",
+			line:    2,
+			reason:  "mapping values are not allowed in this context",
+		},
+		"Type error": {
+			column:  10,
+			content: "name: mock\nruns:\n  using: [docker]\n",
+			line:    3,
+			reason:  "cannot unmarshal !!seq into string",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, message := range PLC10("mock", repofs.CreateFromMap(map[string]string{targetFile: test.content})) {
+				if message.Code == "PLC10001" {
+					assert.Equal(t, check.Fail, message.Status)
+					assert.Equal(t, listCodes()["PLC10001"], message.Message)
+					assert.Equal(t, test.line, message.Line)
+					assert.Equal(t, test.column, message.Column)
+					assert.Equal(t, test.reason, message.Reason)
+				}
+			}
+		})
+	}
+}
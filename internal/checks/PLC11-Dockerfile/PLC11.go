@@ -0,0 +1,165 @@
+package checks
+
+import (
+	"fmt"
+	"internal/check"
+	"internal/dockerfile"
+	"internal/message"
+	"internal/registry"
+	"internal/repofs"
+	"path"
+	"slices"
+	"strings"
+)
+
+const targetFile = "Dockerfile"
+
+var requiredLabels = []string{
+	"org.opencontainers.image.description",
+	"org.opencontainers.image.source",
+	"org.opencontainers.image.title",
+}
+
+func listCodes() map[string]string {
+	return map[string]string{
+		"PLC11001": "The `Dockerfile` MUST be a valid Dockerfile",
+		"PLC11002": "The base images in the `Dockerfile` MUST be pinned to a tag or digest",
+		"PLC11003": "The final stage in the `Dockerfile` MUST set `WORKDIR /code`",
+		"PLC11004": "The final stage in the `Dockerfile` MUST declare `ENV DEFAULTCMD` exactly once",
+		"PLC11005": "The final stage in the `Dockerfile` MUST set the OCI image labels",
+		"PLC11006": "The `Dockerfile` MUST NOT use `ADD` with remote URLs",
+	}
+}
+
+func init() {
+	registry.Register(registry.CreateCheck("PLC11", "Dockerfile", targetFile, listCodes(), func(checkContext registry.Context) []message.Message {
+		return PLC11(checkContext.Files)
+	}))
+}
+
+func isPinned(image string, stageNames []string) bool {
+	pinned := false
+
+	if image == "scratch" || slices.Contains(stageNames, image) || strings.Contains(image, "@") {
+		pinned = true
+	} else {
+		name := image[strings.LastIndex(image, "/")+1:]
+		_, tag, found := strings.Cut(name, ":")
+
+		pinned = found && tag != "" && tag != "latest"
+	}
+
+	return pinned
+}
+
+func isRemote(source string) bool {
+	return strings.Contains(source, "://") || strings.HasPrefix(source, "git@")
+}
+
+func PLC11(files repofs.RepoFS) []message.Message {
+	var (
+		messages []message.Message
+		ok       bool
+	)
+
+	reasons := map[string]string{}
+	status := map[string]check.Status{}
+	codes := listCodes()
+
+	for code := range codes {
+		status[code] = check.Skip
+	}
+
+	fail := func(code string, line int, text string) {
+		delete(status, code)
+
+		messages = append(messages, message.CreateLocatedMessage(check.Fail, code, "", line, 0, text))
+	}
+
+	if _, ok = files.Stat(targetFile); ok {
+		if content, err := files.ReadFile(targetFile); err != nil {
+			reasons["PLC11001"] = fmt.Sprintf("The `%s` could not be read: %v", targetFile, err)
+			status["PLC11001"] = check.Error
+		} else if parsed, err := dockerfile.Parse(content); err != nil {
+			fail("PLC11001", 0, fmt.Sprintf("The `%s` MUST be a valid Dockerfile: %v", targetFile, err))
+		} else {
+			for code := range codes {
+				status[code] = check.Pass
+			}
+
+			var stageNames []string
+
+			for _, stage := range parsed.Stages {
+				if !isPinned(stage.BaseImage, stageNames) {
+					fail("PLC11002", stage.Line, fmt.Sprintf("The base image `%s` MUST be pinned to a tag or digest", stage.BaseImage))
+				}
+
+				if stage.Name != "" {
+					stageNames = append(stageNames, stage.Name)
+				}
+
+				for _, instruction := range stage.Instructions {
+					if instruction.Command == "ADD" && len(instruction.Arguments) > 1 {
+						// The last argument is the destination, all others are sources
+						for _, source := range instruction.Arguments[:len(instruction.Arguments)-1] {
+							if isRemote(source) {
+								fail("PLC11006", instruction.Line, fmt.Sprintf("`ADD` MUST NOT be used with the remote URL `%s`", source))
+							}
+						}
+					}
+				}
+			}
+
+			finalStage, _ := parsed.FinalStage()
+
+			workdir := ""
+			labels := map[string]bool{}
+
+			for _, instruction := range finalStage.Instructions {
+				switch instruction.Command {
+				case "WORKDIR":
+					if len(instruction.Arguments) > 0 {
+						workdir = path.Clean(parsed.Expand(instruction, instruction.Arguments[0]))
+					}
+				case "LABEL":
+					for index := 0; index+1 < len(instruction.Arguments); index += 2 {
+						labels[parsed.Expand(instruction, instruction.Arguments[index])] = true
+					}
+				}
+			}
+
+			if workdir != "/code" {
+				fail("PLC11003", finalStage.Line, "The final stage MUST set `WORKDIR /code`")
+			}
+
+			for _, label := range requiredLabels {
+				if !labels[label] {
+					fail("PLC11005", finalStage.Line, fmt.Sprintf("The final stage MUST set the `%s` label", label))
+				}
+			}
+
+			// Declarations in other stages do not end up in the image
+			declarations := parsed.StageEnv(finalStage, "DEFAULTCMD")
+
+			if len(declarations) == 0 {
+				fail("PLC11004", finalStage.Line, "The final stage MUST declare `ENV DEFAULTCMD`")
+			}
+
+			for index, declaration := range declarations {
+				if index > 0 {
+					fail("PLC11004", declaration.Line, "`ENV DEFAULTCMD` MUST NOT be declared more than once")
+				}
+			}
+		}
+	}
+
+	for code, checkStatus := range status {
+		if checkStatus == check.Error {
+			messages = append(messages, message.CreateErrorMessage(code, codes[code], reasons[code]))
+		} else {
+			messages = append(messages, message.CreateMessage(checkStatus, code, codes[code]))
+		}
+	}
+
+	return messages
+}
@@ -0,0 +1,189 @@
+package checks
+
+import (
+	"github.com/stretchr/testify/assert"
+	"internal/check"
+	"internal/repofs"
+	"strings"
+	"testing"
+)
+
+const mockDockerfile = `FROM pipelinecomponents/base-entrypoint:0.5.0 AS entrypoint
+
+FROM alpine:3.19
+COPY --from=entrypoint /entrypoint.sh /entrypoint.sh
+ENTRYPOINT ["/entrypoint.sh"]
+ENV DEFAULTCMD=mock
+
+WORKDIR /code/
+
+LABEL \
+    org.opencontainers.image.description="Mock description" \
+    org.opencontainers.image.source="https://gitlab.com/pipeline-components/mock" \
+    org.opencontainers.image.title="Mock"
+`
+
+func TestPLC11(t *testing.T) {
+	tests := map[string]struct {
+		files    map[string]string
+		status   map[string]check.Status
+		messages []string
+	}{
+		targetFile + " absent": {
+			files: nil,
+			status: map[string]check.Status{
+				"PLC11001": check.Skip,
+				"PLC11002": check.Skip,
+				"PLC11003": check.Skip,
+				"PLC11004": check.Skip,
+				"PLC11005": check.Skip,
+				"PLC11006": check.Skip,
+			},
+		},
+		targetFile + " is not a valid Dockerfile": {
+			files: map[string]string{targetFile: "ENV DEFAULTCMD"},
+			status: map[string]check.Status{
+				"PLC11001": check.Fail,
+				"PLC11002": check.Skip,
+				"PLC11003": check.Skip,
+				"PLC11004": check.Skip,
+				"PLC11005": check.Skip,
+				"PLC11006": check.Skip,
+			},
+		},
+		targetFile + " following all guidelines": {
+			files: map[string]string{targetFile: mockDockerfile},
+			status: map[string]check.Status{
+				"PLC11001": check.Pass,
+				"PLC11002": check.Pass,
+				"PLC11003": check.Pass,
+				"PLC11004": check.Pass,
+				"PLC11005": check.Pass,
+				"PLC11006": check.Pass,
+			},
+		},
+		targetFile + " with unpinned base images": {
+			files: map[string]string{targetFile: "ARG BASE=alpine\nFROM ${BASE} AS build\nFROM debian:latest\nFROM build\n" +
+				"FROM alpine@sha256:c5b1261d6d3e43071626931fc004f70149baeba2c8ec672bd4f27761f8e1ad6b\n" +
+				"FROM registry.example.com:5000/mock:1.0\nFROM ${UNDEFINED}\n" + mockDockerfile},
+			status: map[string]check.Status{
+				"PLC11001": check.Pass,
+				"PLC11002": check.Fail,
+				"PLC11003": check.Pass,
+				"PLC11004": check.Pass,
+				"PLC11005": check.Pass,
+				"PLC11006": check.Pass,
+			},
+			messages: []string{
+				"Line 2: The base image `alpine` MUST be pinned to a tag or digest",
+				"Line 3: The base image `debian:latest` MUST be pinned to a tag or digest",
+				"Line 7: The base image `${UNDEFINED}` MUST be pinned to a tag or digest",
+			},
+		},
+		targetFile + " with guideline violations in the final stage": {
+			files: map[string]string{targetFile: mockDockerfile + "\nFROM alpine:3.19\nWORKDIR /app\nLABEL org.opencontainers.image.title=Mock\n"},
+			status: map[string]check.Status{
+				"PLC11001": check.Pass,
+				"PLC11002": check.Pass,
+				"PLC11003": check.Fail,
+				"PLC11004": check.Fail,
+				"PLC11005": check.Fail,
+				"PLC11006": check.Pass,
+			},
+			messages: []string{
+				"Line 15: The final stage MUST set `WORKDIR /code`",
+				"Line 15: The final stage MUST set the `org.opencontainers.image.description` label",
+				"Line 15: The final stage MUST set the `org.opencontainers.image.source` label",
+				"Line 15: The final stage MUST declare `ENV DEFAULTCMD`",
+			},
+		},
+		targetFile + " without DEFAULTCMD": {
+			files: map[string]string{targetFile: "FROM alpine:3.19\nWORKDIR /code\n"},
+			status: map[string]check.Status{
+				"PLC11001": check.Pass,
+				"PLC11002": check.Pass,
+				"PLC11003": check.Pass,
+				"PLC11004": check.Fail,
+				"PLC11005": check.Fail,
+				"PLC11006": check.Pass,
+			},
+			messages: []string{
+				"Line 1: The final stage MUST set the `org.opencontainers.image.description` label",
+				"Line 1: The final stage MUST set the `org.opencontainers.image.source` label",
+				"Line 1: The final stage MUST set the `org.opencontainers.image.title` label",
+				"Line 1: The final stage MUST declare `ENV DEFAULTCMD`",
+			},
+		},
+		targetFile + " with DEFAULTCMD declared in a builder stage": {
+			files: map[string]string{targetFile: "FROM alpine:3.19 AS build\nENV DEFAULTCMD=build\n\n" + mockDockerfile},
+			status: map[string]check.Status{
+				"PLC11001": check.Pass,
+				"PLC11002": check.Pass,
+				"PLC11003": check.Pass,
+				"PLC11004": check.Pass,
+				"PLC11005": check.Pass,
+				"PLC11006": check.Pass,
+			},
+		},
+		targetFile + " with DEFAULTCMD only declared in a builder stage": {
+			files: map[string]string{targetFile: "FROM alpine:3.19 AS build\nENV DEFAULTCMD=mock\n\n" + strings.Replace(mockDockerfile, "ENV DEFAULTCMD=mock\n", "", 1)},
+			status: map[string]check.Status{
+				"PLC11001": check.Pass,
+				"PLC11002": check.Pass,
+				"PLC11003": check.Pass,
+				"PLC11004": check.Fail,
+				"PLC11005": check.Pass,
+				"PLC11006": check.Pass,
+			},
+			messages: []string{
+				"Line 6: The final stage MUST declare `ENV DEFAULTCMD`",
+			},
+		},
+		targetFile + " with DEFAULTCMD declared more than once": {
+			files: map[string]string{targetFile: mockDockerfile + "ENV FOO=bar \\\n    DEFAULTCMD=other\n"},
+			status: map[string]check.Status{
+				"PLC11001": check.Pass,
+				"PLC11002": check.Pass,
+				"PLC11003": check.Pass,
+				"PLC11004": check.Fail,
+				"PLC11005": check.Pass,
+				"PLC11006": check.Pass,
+			},
+			messages: []string{
+				"Line 14: `ENV DEFAULTCMD` MUST NOT be declared more than once",
+			},
+		},
+		targetFile + " using ADD with remote URLs": {
+			files: map[string]string{targetFile: mockDockerfile + "ADD --chmod=755 https://example.com/mock.sh ./local.sh /usr/bin/\nADD local.tar.gz /\n"},
+			status: map[string]check.Status{
+				"PLC11001": check.Pass,
+				"PLC11002": check.Pass,
+				"PLC11003": check.Pass,
+				"PLC11004": check.Pass,
+				"PLC11005": check.Pass,
+				"PLC11006": check.Fail,
+			},
+			messages: []string{
+				"Line 14: `ADD` MUST NOT be used with the remote URL `https://example.com/mock.sh`",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			messages := PLC11(repofs.CreateFromMap(test.files))
+
+			var failures []string
+
+			for _, message := range messages {
+				assert.Equal(t, test.status[message.Code], message.Status, "%s expected status %v, got %v", message.Code, test.status[message.Code], message.Status)
+
+				if message.Status == check.Fail && message.Code != "PLC11001" {
+					failures = append(failures, message.Text())
+				}
+			}
+
+			assert.Equal(t, test.messages, failures)
+		})
+	}
+}
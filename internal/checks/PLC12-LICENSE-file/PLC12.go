@@ -2,8 +2,11 @@ package checks
 
 import (
 	"fmt"
+	"internal/asserts"
 	"internal/check"
 	"internal/message"
+	"internal/registry"
+	"internal/repofs"
 	"internal/repositorycontents"
 	"regexp"
 	"strconv"
@@ -26,6 +29,12 @@ func listCodes() map[string]string {
 	}
 }
 
+func init() {
+	registry.Register(registry.CreateCheck("PLC12", "LICENSE file", targetFile, listCodes(), func(checkContext registry.Context) []message.Message {
+		return PLC12(checkContext.Files, checkContext.Skeleton, checkContext.Logs)
+	}))
+}
+
 func getAttributionLine(lines []string) string {
 	var attributionLine string
 
@@ -53,8 +62,8 @@ func getLicenseHolder(attributionLine string) string {
 	return holder
 }
 
-func getLicenseTexts(fileContent string, repo map[string]string) (string, string) {
-	skeletonContent := strings.Join(strings.Split(repo[targetFile], "\n"), " ")
+func getLicenseTexts(fileContent string, skeletonContent string) (string, string) {
+	skeletonContent = strings.Join(strings.Split(skeletonContent, "\n"), " ")
 
 	index := strings.Index(fileContent, seekLicenseText)
 	fileLicenseText := fileContent[index:]
@@ -80,12 +89,13 @@ func getLicenseYears(attributionLine string) (int, int) {
 	return oldestYear, newestYear
 }
 
-func PLC12(files map[string]string, repo map[string]string, logs []repositorycontents.LogEntry) []message.Message {
+func PLC12(files repofs.RepoFS, repo repofs.RepoFS, logs []repositorycontents.LogEntry) []message.Message {
 	var (
 		messages []message.Message
 		ok       bool
 	)
 
+	reasons := map[string]string{}
 	status := map[string]check.Status{}
 	codes := listCodes()
 
@@ -93,9 +103,15 @@ func PLC12(files map[string]string, repo map[string]string, logs []repositorycon
 		status[code] = check.Skip
 	}
 
-	if _, ok = files[targetFile]; ok {
-		if _, repoFileExists := repo[targetFile]; !repoFileExists {
-			codes["PLC12001"] = fmt.Sprintf("The required `%s` file is missing from the skeleton repository", targetFile)
+	if _, ok = files.Stat(targetFile); ok {
+		if _, repoFileExists := repo.Stat(targetFile); !repoFileExists {
+			reasons["PLC12001"] = fmt.Sprintf("The required `%s` file is missing from the skeleton repository", targetFile)
+			status["PLC12001"] = check.Error
+		} else if skeletonContent, err := repo.ReadFile(targetFile); err != nil {
+			reasons["PLC12001"] = fmt.Sprintf("The `%s` file in the skeleton repository could not be read: %v", targetFile, err)
+			status["PLC12001"] = check.Error
+		} else if fileContent, err := files.ReadFile(targetFile); err != nil {
+			reasons["PLC12001"] = fmt.Sprintf("The `%s` file could not be read: %v", targetFile, err)
 			status["PLC12001"] = check.Error
 		} else {
 			for code := range codes {
@@ -104,18 +120,24 @@ func PLC12(files map[string]string, repo map[string]string, logs []repositorycon
 				}
 			}
 
-			fileContent := files[targetFile]
-
 			lines := strings.Split(fileContent, "\n")
 
 			if strings.Contains(fileContent, seekLicenseName) {
 				if strings.Contains(fileContent, seekLicenseText) {
-					fileLicenseText, skeletonLicenseText := getLicenseTexts(fileContent, repo)
+					fileLicenseText, skeletonLicenseText := getLicenseTexts(fileContent, skeletonContent)
 
 					if fileLicenseText == skeletonLicenseText {
 						status["PLC12001"] = check.Pass
 					} else {
-						// TODO: Add a message that shows the difference between the two files
+						delete(status, "PLC12001")
+
+						messages = append(messages, asserts.CreateDiffMessage(
+							"PLC12001",
+							codes["PLC12001"],
+							targetFile,
+							fileContent[strings.Index(fileContent, seekLicenseText):],
+							skeletonContent[max(strings.Index(skeletonContent, seekLicenseText), 0):],
+						))
 					}
 				}
 			}
@@ -130,7 +152,7 @@ func PLC12(files map[string]string, repo map[string]string, logs []repositorycon
 
 				if oldestYear != 0 {
 					if len(logs) == 0 {
-						codes["PLC12003"] = fmt.Sprintf("No log entries found for the repository")
+						reasons["PLC12003"] = fmt.Sprintf("No log entries found for the repository")
 						status["PLC12003"] = check.Error
 					} else {
 						firstCommit := logs[0].Timestamp.Year()
@@ -165,7 +187,11 @@ func PLC12(files map[string]string, repo map[string]string, logs []repositorycon
 	}
 
 	for code, checkStatus := range status {
-		messages = append(messages, message.CreateMessage(checkStatus, code, codes[code]))
+		if checkStatus == check.Error {
+			messages = append(messages, message.CreateErrorMessage(code, codes[code], reasons[code]))
+		} else {
+			messages = append(messages, message.CreateMessage(checkStatus, code, codes[code]))
+		}
 	}
 
 	return messages
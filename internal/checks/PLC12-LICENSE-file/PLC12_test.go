@@ -3,6 +3,7 @@ package checks
 import (
 	"github.com/stretchr/testify/assert"
 	"internal/check"
+	"internal/repofs"
 	"internal/repositorycontents"
 	"testing"
 	"time"
@@ -261,7 +262,7 @@ func TestPLC12(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			messages := PLC12(test.files, test.repo, test.logs)
+			messages := PLC12(repofs.CreateFromMap(test.files), repofs.CreateFromMap(test.repo), test.logs)
 
 			for _, message := range messages {
 				assert.Equal(t, test.status[message.Code], message.Status, "%s expected status %v, got %v", message.Code, test.status[message.Code], message.Status)
@@ -1,6 +1,7 @@
 package checks
 
 import (
+	"context"
 	"fmt"
 	"github.com/gomarkdown/markdown"
 	"github.com/gomarkdown/markdown/ast"
@@ -8,7 +9,12 @@ import (
 	"github.com/gomarkdown/markdown/parser"
 	"internal/asserts"
 	"internal/check"
+	"internal/httpclient"
+	"internal/markdownlint"
 	"internal/message"
+	"internal/registry"
+	"internal/repofs"
+	"path"
 	"reflect"
 	"regexp"
 	"strings"
@@ -18,14 +24,14 @@ const targetFile = "README.md"
 
 func listCodes() map[string]string {
 	return map[string]string{
-		"PLC13001": "🤖 The `README.md` file MUST pass the linting rules defined in `.mdlrc`",
+		"PLC13001": "The `README.md` file MUST pass the linting rules defined in `.mdlrc`",
 		"PLC13002": "The `README.md` file MUST contain `# Pipeline Components: <component-name>` heading as the first line",
 		"PLC13003": "The lines directly after the heading MUST contain the same badges/shields as the `README.md` file in the skeleton repository",
 		"PLC13004": "The `README.md` file MUST contain the same main sections, in the same order, as the `README.md` file in the skeleton repository",
 		"PLC13005": "The 'Versioning' section in the `README.md` file MUST be identical to their counterparts in the `README.md` file in the skeleton repository",
 		"PLC13006": "The 'Support' section in the `README.md` file MUST be identical to their counterparts in the `README.md` file in the skeleton repository",
 		"PLC13007": "The 'Contributing' section in the `README.md` file MUST be identical to their counterparts in the `README.md` file in the skeleton repository",
-		"PLC13008": "⁉ The 'Examples' section in the `README.md` file MUST be auto-generated from a separate example file in the repository",
+		"PLC13008": "The 'Examples' section in the `README.md` file MUST be auto-generated from a separate example file in the repository",
 		"PLC13009": "The 'Authors & contributors' section in the `README.md` file MUST state the author who initially set up the repository",
 		"PLC13010": "The 'Authors & contributors' section in the `README.md` file MAY contain a link for the initial author",
 		"PLC13011": "The link for the initial author in the 'Authors & contributors' section in the `README.md` file, if present, MUST resolve",
@@ -39,6 +45,12 @@ func listCodes() map[string]string {
 	}
 }
 
+func init() {
+	registry.Register(registry.CreateCheck("PLC13", "README.md file", targetFile, listCodes(), func(checkContext registry.Context) []message.Message {
+		return PLC13(checkContext.Context, checkContext.HTTPClient, checkContext.ComponentName, checkContext.Files, checkContext.Skeleton)
+	}))
+}
+
 func appendNodeToDocument(parent *ast.Document, child ast.Node) {
 	child.SetParent(parent)
 	newChildren := append(parent.GetChildren(), child)
@@ -57,6 +69,31 @@ func contentToString(literal []byte, content []byte) string {
 	return result
 }
 
+// getExamplesSection renders the 'Examples' section the way it would be
+// generated from the given example files: one fenced code block per file, in
+// the order of the file names, with the file extension as the language (and
+// `yml` written as `yaml`). The format is documented in the README of plc-lint.
+func getExamplesSection(files repofs.RepoFS, exampleFiles []string) string {
+	content := "## Examples\n\n"
+
+	for _, exampleFile := range exampleFiles {
+		language := strings.TrimPrefix(path.Ext(exampleFile), ".")
+
+		if language == "yml" {
+			language = "yaml"
+		}
+
+		example, _ := files.ReadFile(exampleFile)
+
+		content += fmt.Sprintf("```%s\n%s\n```\n\n", language, strings.TrimRight(example, "\n"))
+	}
+
+	examplesParser := parser.NewWithExtensions(parser.CommonExtensions)
+	examplesDocument := examplesParser.Parse([]byte(content))
+
+	return getSections(examplesDocument)["Examples"]
+}
+
 func getContentFromNode(node ast.Node) string {
 	var content string
 
@@ -115,6 +152,21 @@ func getHeadings(document ast.Node, minLevel int, maxLevel int) []struct {
 	return headings
 }
 
+// listHeadings puts the given headings on a line each, so they can be diffed
+func listHeadings(headings []struct {
+	content string
+	index   int
+	level   int
+}) string {
+	list := ""
+
+	for _, heading := range headings {
+		list += fmt.Sprintf("%s %s\n", strings.Repeat("#", heading.level), heading.content)
+	}
+
+	return list
+}
+
 func getSections(document ast.Node) map[string]string {
 	var (
 		currentSectionName string
@@ -162,22 +214,43 @@ func getSections(document ast.Node) map[string]string {
 	return sections
 }
 
-func PLC13(componentName string, files map[string]string, repo map[string]string) []message.Message {
+// checkUrl passes the given code when the URL resolves. When the check was
+// stopped before the URL was checked, nothing is known about it, so the code
+// is Incomplete rather than failed.
+func checkUrl(ctx context.Context, client *httpclient.Client, url string, code string, status map[string]check.Status, reasons map[string]string) {
+	resolves, err := client.Resolves(ctx, url)
+
+	if err != nil {
+		status[code] = check.Incomplete
+		reasons[code] = fmt.Sprintf("The link could not be checked: %v", err)
+	} else if resolves {
+		status[code] = check.Pass
+	}
+}
+
+func PLC13(ctx context.Context, client *httpclient.Client, componentName string, files repofs.RepoFS, repo repofs.RepoFS) []message.Message {
 	var (
 		messages []message.Message
 		ok       bool
 	)
 
 	codes := listCodes()
+	reasons := map[string]string{}
 	status := map[string]check.Status{}
 
 	for code := range codes {
 		status[code] = check.Skip
 	}
 
-	if _, ok = files[targetFile]; ok {
-		if _, repoFileExists := repo[targetFile]; !repoFileExists {
-			codes["PLC13002"] = fmt.Sprintf("The required `%s` file is missing from the skeleton repository", targetFile)
+	if _, ok = files.Stat(targetFile); ok {
+		if _, repoFileExists := repo.Stat(targetFile); !repoFileExists {
+			reasons["PLC13002"] = fmt.Sprintf("The required `%s` file is missing from the skeleton repository", targetFile)
+			status["PLC13002"] = check.Error
+		} else if skeletonContent, err := repo.ReadFile(targetFile); err != nil {
+			reasons["PLC13002"] = fmt.Sprintf("The `%s` file in the skeleton repository could not be read: %v", targetFile, err)
+			status["PLC13002"] = check.Error
+		} else if subjectContent, err := files.ReadFile(targetFile); err != nil {
+			reasons["PLC13002"] = fmt.Sprintf("The `%s` file could not be read: %v", targetFile, err)
 			status["PLC13002"] = check.Error
 		} else {
 			for _, code := range []string{"PLC13002", "PLC13003", "PLC13004"} {
@@ -185,10 +258,41 @@ func PLC13(componentName string, files map[string]string, repo map[string]string
 			}
 
 			subjectParser := parser.NewWithExtensions(parser.CommonExtensions)
-			subjectMarkdown := []byte(files[targetFile])
+			subjectMarkdown := []byte(subjectContent)
 			subjectDocument := subjectParser.Parse(subjectMarkdown)
 			subjectSections := getSections(subjectDocument)
 
+			if _, ok := files.Stat(".mdlrc"); ok {
+				var config markdownlint.Config
+
+				mdlrc, err := files.ReadFile(".mdlrc")
+
+				if err == nil {
+					config, err = markdownlint.ParseConfig(mdlrc, files)
+				}
+
+				if err != nil {
+					reasons["PLC13001"] = fmt.Sprintf("The `.mdlrc` file could not be read: %s", err)
+					status["PLC13001"] = check.Error
+				} else if violations := markdownlint.Lint(subjectMarkdown, subjectDocument, config); len(violations) == 0 {
+					status["PLC13001"] = check.Pass
+				} else {
+					// Report each linting violation on its own
+					delete(status, "PLC13001")
+
+					for _, violation := range violations {
+						messages = append(messages, message.CreateLocatedMessage(
+							check.Fail,
+							"PLC13001",
+							"",
+							violation.Line,
+							0,
+							fmt.Sprintf("%s %s", violation.Rule, violation.Description),
+						))
+					}
+				}
+			}
+
 			subjectHeadings := getHeadings(subjectDocument, 1, 1)
 
 			if len(subjectHeadings) > 0 && strings.HasPrefix(subjectHeadings[0].content, "Pipeline Components: ") {
@@ -196,20 +300,40 @@ func PLC13(componentName string, files map[string]string, repo map[string]string
 			}
 
 			skeletonParser := parser.NewWithExtensions(parser.CommonExtensions)
-			skeletonMarkdown := []byte(repo[targetFile])
+			skeletonMarkdown := []byte(skeletonContent)
 			skeletonDocument := skeletonParser.Parse(skeletonMarkdown)
 			skeletonHeadings := getHeadings(skeletonDocument, 2, 2)
 			skeletonSections := getSections(skeletonDocument)
 
-			if _, ok := subjectSections["__ROOT__"]; ok && subjectSections["__ROOT__"] == skeletonSections["__ROOT__"] {
-				status["PLC13003"] = check.Pass
+			if _, ok := subjectSections["__ROOT__"]; ok {
+				if subjectSections["__ROOT__"] == skeletonSections["__ROOT__"] {
+					status["PLC13003"] = check.Pass
+				} else {
+					delete(status, "PLC13003")
+					messages = append(messages, asserts.CreateDiffMessage(
+						"PLC13003",
+						codes["PLC13003"],
+						targetFile+" (badges)",
+						subjectSections["__ROOT__"],
+						skeletonSections["__ROOT__"],
+					))
+				}
 			}
 
 			subjectHeadings = getHeadings(subjectDocument, 2, 2)
 
 			if reflect.DeepEqual(subjectHeadings, skeletonHeadings) {
 				status["PLC13004"] = check.Pass
-			} // else { @TODO: Show the differences between the headings }
+			} else {
+				delete(status, "PLC13004")
+				messages = append(messages, asserts.CreateDiffMessage(
+					"PLC13004",
+					codes["PLC13004"],
+					targetFile+" (sections)",
+					listHeadings(subjectHeadings),
+					listHeadings(skeletonHeadings),
+				))
+			}
 
 			for checkCode, sectionName := range map[string]string{
 				"PLC13005": "Versioning",
@@ -220,7 +344,45 @@ func PLC13(componentName string, files map[string]string, repo map[string]string
 					if subjectSections[sectionName] == skeletonSections[sectionName] {
 						status[checkCode] = check.Pass
 					} else {
-						status[checkCode] = check.Fail
+						delete(status, checkCode)
+						messages = append(messages, asserts.CreateDiffMessage(
+							checkCode,
+							codes[checkCode],
+							fmt.Sprintf("%s (%s)", targetFile, sectionName),
+							subjectSections[sectionName],
+							skeletonSections[sectionName],
+						))
+					}
+				}
+			}
+
+			exampleFiles := asserts.ListFolderFiles(files, "examples/")
+
+			if _, ok := subjectSections["Examples"]; ok && len(exampleFiles) > 0 {
+				if subjectSections["Examples"] == getExamplesSection(files, exampleFiles) {
+					status["PLC13008"] = check.Pass
+				} else {
+					// Report each example that is not (or no longer) in the README on its own
+					delete(status, "PLC13008")
+
+					staleExamples := 0
+
+					for _, exampleFile := range exampleFiles {
+						example, _ := files.ReadFile(exampleFile)
+
+						if !strings.Contains(subjectSections["Examples"], strings.TrimRight(example, "\n")) {
+							staleExamples++
+
+							messages = append(messages, message.CreateMessage(
+								check.Fail,
+								"PLC13008",
+								fmt.Sprintf("The 'Examples' section in the `README.md` file is stale, it does not match the `%s` example file", exampleFile),
+							))
+						}
+					}
+
+					if staleExamples == 0 {
+						messages = append(messages, message.CreateMessage(check.Fail, "PLC13008", codes["PLC13008"]))
 					}
 				}
 			}
@@ -249,9 +411,7 @@ func PLC13(componentName string, files map[string]string, repo map[string]string
 								matches := linkPattern.FindStringSubmatch(split[1])
 								url := matches[linkPattern.SubexpIndex("URL")]
 
-								if asserts.UrlResolves(url) {
-									status["PLC13011"] = check.Pass
-								}
+								checkUrl(ctx, client, url, "PLC13011", status, reasons)
 							}
 						}
 					} else if strings.Contains(line, "contributor's page") {
@@ -266,9 +426,7 @@ func PLC13(componentName string, files map[string]string, repo map[string]string
 								status["PLC13012"] = check.Pass
 								status["PLC13013"] = check.Fail
 
-								if asserts.UrlResolves(url) {
-									status["PLC13013"] = check.Pass
-								}
+								checkUrl(ctx, client, url, "PLC13013", status, reasons)
 							}
 						}
 					}
@@ -293,9 +451,7 @@ func PLC13(componentName string, files map[string]string, repo map[string]string
 								matches := linkPattern.FindStringSubmatch(split[1])
 								url := matches[linkPattern.SubexpIndex("URL")]
 
-								if asserts.UrlResolves(url) {
-									status["PLC13015"] = check.Pass
-								}
+								checkUrl(ctx, client, url, "PLC13015", status, reasons)
 							}
 						}
 					}
@@ -321,9 +477,7 @@ func PLC13(componentName string, files map[string]string, repo map[string]string
 										url,
 									)
 
-									if asserts.UrlResolves(url) {
-										status["PLC13018"] = check.Pass
-									}
+									checkUrl(ctx, client, url, "PLC13018", status, reasons)
 								}
 							}
 						}
@@ -334,7 +488,13 @@ func PLC13(componentName string, files map[string]string, repo map[string]string
 	}
 
 	for code, checkStatus := range status {
-		messages = append(messages, message.CreateMessage(checkStatus, code, codes[code]))
+		if checkStatus == check.Error {
+			messages = append(messages, message.CreateErrorMessage(code, codes[code], reasons[code]))
+		} else if checkStatus == check.Incomplete {
+			messages = append(messages, message.CreateIncompleteMessage(code, codes[code], reasons[code]))
+		} else {
+			messages = append(messages, message.CreateMessage(checkStatus, code, codes[code]))
+		}
 	}
 
 	return messages
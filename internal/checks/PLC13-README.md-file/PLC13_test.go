@@ -1,8 +1,16 @@
 package checks
 
 import (
+	"context"
+	"github.com/gomarkdown/markdown/parser"
 	"github.com/stretchr/testify/assert"
+	"internal/asserts"
 	"internal/check"
+	"internal/httpclienttest"
+	"internal/repofs"
+	"net/http"
+	"net/http/httptest"
+	"path"
 	"strings"
 	"testing"
 )
@@ -10,9 +18,10 @@ import (
 const mockCorrectHeader = "# Pipeline Components: Mock\n"
 const mockIncorrectHeader = "# Mock Header\n"
 
-const mockCorrectSections = `
+const mockSections = `
 ## Usage
 ## Examples
+{{ .examples_section }}
 ## Versioning
 {{ .versioning_section }}
 ## Support
@@ -34,6 +43,31 @@ const mockIncorrectSections = `
 const mockBadges = "[![A](B)](C)\n"
 const mockOtherBadges = "[![D](E)](F)\n"
 
+// mockUrls are the paths of the URLs in the tests that resolve, any other path is not found
+var mockUrls = map[string]int{
+	"/mjrider": http.StatusOK,
+	"/pipeline-components/org/skeleton/-/blob/HEAD/LICENSE": http.StatusOK,
+	"/pipeline-components/org/skeleton/-/graphs/main":       http.StatusOK,
+	"/status/200": http.StatusOK,
+	"/status/500": http.StatusInternalServerError,
+}
+
+func createMockServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		statusCode, ok := mockUrls[path.Clean(request.URL.Path)]
+
+		if !ok {
+			statusCode = http.StatusNotFound
+		}
+
+		writer.WriteHeader(statusCode)
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
 func populateTemplate(templateContent string, replace map[string]string) string {
 	for A, B := range replace {
 		templateContent = strings.Replace(templateContent, "{{ ."+A+" }}", B, -1)
@@ -225,6 +259,114 @@ func TestPLC13(t *testing.T) {
 				"PLC13018": check.Fail,
 			},
 		},
+		targetFile + " with examples section generated from the example files": {
+			files: map[string]string{
+				targetFile: mockIncorrectHeader + mockBadges +
+					populateTemplate(mockSections, map[string]string{
+						"author_section":       "",
+						"contributing_section": "",
+						"examples_section":     "```yaml\nmock: example\n```",
+						"license_section":      "",
+						"support_section":      "",
+						"versioning_section":   "",
+					}),
+				"examples/":              "",
+				"examples/gitlab-ci.yml": "mock: example\n",
+			},
+			repo: map[string]string{targetFile: mockIncorrectHeader + mockBadges + mockSections},
+			status: map[string]check.Status{
+				"PLC13001": check.Skip,
+				"PLC13002": check.Fail,
+				"PLC13003": check.Pass,
+				"PLC13004": check.Pass,
+				"PLC13005": check.Fail,
+				"PLC13006": check.Fail,
+				"PLC13007": check.Fail,
+				"PLC13008": check.Pass,
+				"PLC13009": check.Fail,
+				"PLC13010": check.Skip,
+				"PLC13011": check.Skip,
+				"PLC13012": check.Fail,
+				"PLC13013": check.Fail,
+				"PLC13014": check.Fail,
+				"PLC13015": check.Fail,
+				"PLC13016": check.Fail,
+				"PLC13017": check.Fail,
+				"PLC13018": check.Fail,
+			},
+		},
+		targetFile + " with examples section not matching the example files": {
+			files: map[string]string{
+				targetFile: mockIncorrectHeader + mockBadges +
+					populateTemplate(mockSections, map[string]string{
+						"author_section":       "",
+						"contributing_section": "",
+						"examples_section":     "```yaml\nmock: stale\n```",
+						"license_section":      "",
+						"support_section":      "",
+						"versioning_section":   "",
+					}),
+				"examples/":              "",
+				"examples/gitlab-ci.yml": "mock: example\n",
+			},
+			repo: map[string]string{targetFile: mockIncorrectHeader + mockBadges + mockSections},
+			status: map[string]check.Status{
+				"PLC13001": check.Skip,
+				"PLC13002": check.Fail,
+				"PLC13003": check.Pass,
+				"PLC13004": check.Pass,
+				"PLC13005": check.Fail,
+				"PLC13006": check.Fail,
+				"PLC13007": check.Fail,
+				"PLC13008": check.Fail,
+				"PLC13009": check.Fail,
+				"PLC13010": check.Skip,
+				"PLC13011": check.Skip,
+				"PLC13012": check.Fail,
+				"PLC13013": check.Fail,
+				"PLC13014": check.Fail,
+				"PLC13015": check.Fail,
+				"PLC13016": check.Fail,
+				"PLC13017": check.Fail,
+				"PLC13018": check.Fail,
+			},
+		},
+		targetFile + " with examples section containing more than the example files": {
+			files: map[string]string{
+				targetFile: mockIncorrectHeader + mockBadges +
+					populateTemplate(mockSections, map[string]string{
+						"author_section":       "",
+						"contributing_section": "",
+						"examples_section":     "Mock text\n\n```yaml\nmock: example\n```",
+						"license_section":      "",
+						"support_section":      "",
+						"versioning_section":   "",
+					}),
+				"examples/":              "",
+				"examples/gitlab-ci.yml": "mock: example\n",
+			},
+			repo: map[string]string{targetFile: mockIncorrectHeader + mockBadges + mockSections},
+			status: map[string]check.Status{
+				"PLC13001": check.Skip,
+				"PLC13002": check.Fail,
+				"PLC13003": check.Pass,
+				"PLC13004": check.Pass,
+				"PLC13005": check.Fail,
+				"PLC13006": check.Fail,
+				"PLC13007": check.Fail,
+				"PLC13008": check.Fail,
+				"PLC13009": check.Fail,
+				"PLC13010": check.Skip,
+				"PLC13011": check.Skip,
+				"PLC13012": check.Fail,
+				"PLC13013": check.Fail,
+				"PLC13014": check.Fail,
+				"PLC13015": check.Fail,
+				"PLC13016": check.Fail,
+				"PLC13017": check.Fail,
+				"PLC13018": check.Fail,
+			},
+		},
 		targetFile + " with author without link": {
 			files: map[string]string{targetFile: mockIncorrectHeader + mockBadges +
 				populateTemplate(mockSections, map[string]string{
@@ -574,11 +716,109 @@ func TestPLC13(t *testing.T) {
 				"PLC13018": check.Pass,
 			},
 		},
+		targetFile + " passing the rules in .mdlrc": {
+			files: map[string]string{targetFile: "# Mock File content\n", ".mdlrc": "rules \"MD001,MD041\"\n"},
+			repo:  map[string]string{targetFile: "# Mock File content\n"},
+			status: map[string]check.Status{
+				"PLC13001": check.Pass,
+				"PLC13002": check.Fail,
+				"PLC13003": check.Pass,
+				"PLC13004": check.Pass,
+				"PLC13005": check.Skip,
+				"PLC13006": check.Skip,
+				"PLC13007": check.Skip,
+				"PLC13008": check.Skip,
+				"PLC13009": check.Skip,
+				"PLC13010": check.Skip,
+				"PLC13011": check.Skip,
+				"PLC13012": check.Skip,
+				"PLC13013": check.Skip,
+				"PLC13014": check.Skip,
+				"PLC13015": check.Skip,
+				"PLC13016": check.Skip,
+				"PLC13017": check.Skip,
+				"PLC13018": check.Skip,
+			},
+		},
+		targetFile + " failing the rules in .mdlrc": {
+			files: map[string]string{targetFile: "# Mock File content\n\n### Mock Section\n", ".mdlrc": "rules \"MD001\"\n"},
+			repo:  map[string]string{targetFile: "# Mock File content\n"},
+			status: map[string]check.Status{
+				"PLC13001": check.Fail,
+				"PLC13002": check.Fail,
+				"PLC13003": check.Fail,
+				"PLC13004": check.Pass,
+				"PLC13005": check.Skip,
+				"PLC13006": check.Skip,
+				"PLC13007": check.Skip,
+				"PLC13008": check.Skip,
+				"PLC13009": check.Skip,
+				"PLC13010": check.Skip,
+				"PLC13011": check.Skip,
+				"PLC13012": check.Skip,
+				"PLC13013": check.Skip,
+				"PLC13014": check.Skip,
+				"PLC13015": check.Skip,
+				"PLC13016": check.Skip,
+				"PLC13017": check.Skip,
+				"PLC13018": check.Skip,
+			},
+		},
+		targetFile + " with .mdlrc referring to a missing style file": {
+			files: map[string]string{targetFile: "# Mock File content\n", ".mdlrc": "style \".mdlrc.rb\"\n"},
+			repo:  map[string]string{targetFile: "# Mock File content\n"},
+			status: map[string]check.Status{
+				"PLC13001": check.Error,
+				"PLC13002": check.Fail,
+				"PLC13003": check.Pass,
+				"PLC13004": check.Pass,
+				"PLC13005": check.Skip,
+				"PLC13006": check.Skip,
+				"PLC13007": check.Skip,
+				"PLC13008": check.Skip,
+				"PLC13009": check.Skip,
+				"PLC13010": check.Skip,
+				"PLC13011": check.Skip,
+				"PLC13012": check.Skip,
+				"PLC13013": check.Skip,
+				"PLC13014": check.Skip,
+				"PLC13015": check.Skip,
+				"PLC13016": check.Skip,
+				"PLC13017": check.Skip,
+				"PLC13018": check.Skip,
+			},
+		},
+		targetFile + " with .mdlrc with an unknown setting": {
+			files: map[string]string{targetFile: "# Mock File content\n", ".mdlrc": "rule \"MD001\"\n"},
+			repo:  map[string]string{targetFile: "# Mock File content\n"},
+			status: map[string]check.Status{
+				"PLC13001": check.Error,
+				"PLC13002": check.Fail,
+				"PLC13003": check.Pass,
+				"PLC13004": check.Pass,
+				"PLC13005": check.Skip,
+				"PLC13006": check.Skip,
+				"PLC13007": check.Skip,
+				"PLC13008": check.Skip,
+				"PLC13009": check.Skip,
+				"PLC13010": check.Skip,
+				"PLC13011": check.Skip,
+				"PLC13012": check.Skip,
+				"PLC13013": check.Skip,
+				"PLC13014": check.Skip,
+				"PLC13015": check.Skip,
+				"PLC13016": check.Skip,
+				"PLC13017": check.Skip,
+				"PLC13018": check.Skip,
+			},
+		},
 	}
 
+	client := httpclienttest.CreateClient(createMockServer(t))
+
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			messages := PLC13("org/skeleton", test.files, test.repo)
+			messages := PLC13(context.Background(), client, "org/skeleton", repofs.CreateFromMap(test.files), repofs.CreateFromMap(test.repo))
 
 			for _, message := range messages {
 				assert.Equal(t, test.status[message.Code], message.Status, "%s expected status %v, got %v", message.Code, test.status[message.Code], message.Status)
@@ -586,3 +826,105 @@ func TestPLC13(t *testing.T) {
 		})
 	}
 }
+
+func TestPLC13Stopped(t *testing.T) {
+	files := map[string]string{targetFile: mockCorrectHeader + mockBadges +
+		populateTemplate(mockSections, map[string]string{
+			"author_section":  "The original setup of this repository is by [Mock Author](https://httpbin.org/status/200)\nFor a full list of all authors and contributors, check [the contributor's page][contributors].\n[contributors]: https://gitlab.com/pipeline-components/_template_/-/graphs/main",
+			"license_section": "Created by [Robbert Müller][mjrider], licensed under a [MIT license][license-link]\n[mjrider]: https://gitlab.com/mjrider\n[license-link]: ./LICENSE\n",
+		}),
+	}
+	repo := map[string]string{targetFile: mockIncorrectHeader + mockBadges + mockSections}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Links that were not checked do not fail
+	expected := map[string]check.Status{
+		"PLC13011": check.Incomplete,
+		"PLC13013": check.Incomplete,
+		"PLC13015": check.Incomplete,
+		"PLC13018": check.Incomplete,
+	}
+
+	messages := PLC13(ctx, httpclienttest.CreateClient(createMockServer(t)), "org/skeleton", repofs.CreateFromMap(files), repofs.CreateFromMap(repo))
+
+	for _, message := range messages {
+		if status, ok := expected[message.Code]; ok {
+			assert.Equal(t, status, message.Status, "%s expected status %v, got %v", message.Code, status, message.Status)
+			assert.Contains(t, message.Reason, "The link could not be checked: checking 'https://")
+		}
+	}
+}
+
+// mockComponentReadme is the `README.md` file of a component, as it is generated from its example file
+const mockComponentReadme = "# Pipeline Components: Yamllint\n" +
+	"\n" +
+	"[![][gitlab-ci-badge]][gitlab-ci]\n" +
+	"\n" +
+	"## Usage\n" +
+	"\n" +
+	"The image is for running yamllint, yamllint is installed in /app/ in case you need to customize the install before usage.\n" +
+	"\n" +
+	"## Examples\n" +
+	"\n" +
+	"```yaml\n" +
+	"yamllint:\n" +
+	"  stage: linting\n" +
+	"  image: registry.gitlab.com/pipeline-components/yamllint:latest\n" +
+	"  script:\n" +
+	"    - yamllint .\n" +
+	"```\n" +
+	"\n" +
+	"## Versioning\n" +
+	"\n" +
+	"This project uses [Semantic Versioning](https://semver.org/) for its version numbering.\n" +
+	"\n" +
+	"[gitlab-ci]: https://gitlab.com/pipeline-components/yamllint/commits/main\n" +
+	"[gitlab-ci-badge]: https://gitlab.com/pipeline-components/yamllint/badges/main/pipeline.svg\n"
+
+const mockComponentExample = "yamllint:\n" +
+	"  stage: linting\n" +
+	"  image: registry.gitlab.com/pipeline-components/yamllint:latest\n" +
+	"  script:\n" +
+	"    - yamllint .\n"
+
+func TestGetExamplesSection(t *testing.T) {
+	tests := map[string]struct {
+		files    map[string]string
+		readme   string
+		expected bool
+	}{
+		"Component README generated from its example file": {
+			files:    map[string]string{"examples/gitlab-ci.yml": mockComponentExample},
+			readme:   mockComponentReadme,
+			expected: true,
+		},
+		"Component README with an explanation before the example": {
+			files:    map[string]string{"examples/gitlab-ci.yml": mockComponentExample},
+			readme:   strings.Replace(mockComponentReadme, "## Examples\n\n", "## Examples\n\nAdd this job to your pipeline:\n\n", 1),
+			expected: false,
+		},
+		"Component README with an example that was changed": {
+			files:    map[string]string{"examples/gitlab-ci.yml": strings.Replace(mockComponentExample, "linting", "test", 1)},
+			readme:   mockComponentReadme,
+			expected: false,
+		},
+		"Component README with an example file that is not in it": {
+			files:    map[string]string{"examples/gitlab-ci.yml": mockComponentExample, "examples/yamllint.sh": "yamllint .\n"},
+			readme:   mockComponentReadme,
+			expected: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			files := repofs.CreateFromMap(test.files)
+			document := parser.NewWithExtensions(parser.CommonExtensions).Parse([]byte(test.readme))
+
+			actual := getSections(document)["Examples"] == getExamplesSection(files, asserts.ListFolderFiles(files, "examples/"))
+
+			assert.Equal(t, test.expected, actual, "%s expected a match %v, got %v", name, test.expected, actual)
+		})
+	}
+}
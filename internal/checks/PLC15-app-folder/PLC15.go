@@ -3,7 +3,10 @@ package checks
 import (
 	"internal/asserts"
 	"internal/check"
+	"internal/fix"
 	"internal/message"
+	"internal/registry"
+	"internal/repofs"
 )
 
 func listCodes() map[string]string {
@@ -14,7 +17,15 @@ func listCodes() map[string]string {
 	}
 }
 
-func PLC15(files map[string]string) []message.Message {
+func init() {
+	registry.Register(registry.CreateFixableCheck("PLC15", "app folder", "app/", listCodes(), func(checkContext registry.Context) []message.Message {
+		return PLC15(checkContext.Files)
+	}, func(checkContext registry.Context, messages []message.Message) []fix.Change {
+		return fix.CreateFolders(map[string]string{"app/": "PLC15001"}, messages)
+	}))
+}
+
+func PLC15(files repofs.RepoFS) []message.Message {
 	var (
 		messages []message.Message
 		ok       bool
@@ -30,17 +41,17 @@ func PLC15(files map[string]string) []message.Message {
 
 	targetFile := "app/"
 
-	if _, ok = files[targetFile]; !ok {
+	if _, ok = files.Stat(targetFile); !ok {
 		status["PLC15001"] = check.Skip
 	} else {
 		empty := asserts.DirectoryIsEmpty(files, targetFile)
 
 		if !empty {
 			status["PLC15001"] = check.Pass
-			if _, ok = files["app/.gitkeep"]; ok {
+			if gitkeep, ok := files.Stat("app/.gitkeep"); ok {
 				status["PLC15002"] = check.Pass
 
-				if len(files["app/.gitkeep"]) == 0 {
+				if gitkeep.Size == 0 {
 					status["PLC15003"] = check.Pass
 				} else {
 					status["PLC15003"] = check.Fail
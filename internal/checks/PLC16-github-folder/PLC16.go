@@ -2,10 +2,43 @@ package checks
 
 import (
 	"internal/asserts"
+	"internal/fix"
 	"internal/message"
+	"internal/registry"
+	"internal/repofs"
 )
 
-func PLC16(files map[string]string) []message.Message {
+func listCodes() map[string]string {
+	return map[string]string{
+		"PLC16001": "The repository MUST contain a `.github/FUNDING.yml` file",
+		"PLC16002": "The repository MUST contain a `.github/workflows/` directory",
+	}
+}
+
+func listFiles() map[string]string {
+	return map[string]string{
+		".github/FUNDING.yml": "PLC16001",
+	}
+}
+
+func listFolders() map[string]string {
+	return map[string]string{
+		".github/workflows/": "PLC16002",
+	}
+}
+
+func init() {
+	registry.Register(registry.CreateFixableCheck("PLC16", ".github folder", ".github/", listCodes(), func(checkContext registry.Context) []message.Message {
+		return PLC16(checkContext.Files)
+	}, func(checkContext registry.Context, messages []message.Message) []fix.Change {
+		return append(
+			fix.CopyFromSkeleton(checkContext.Files, checkContext.Skeleton, listFiles(), messages),
+			fix.CreateFolders(listFolders(), messages)...,
+		)
+	}))
+}
+
+func PLC16(files repofs.RepoFS) []message.Message {
 	var messages []message.Message
 
 	messages = append(messages, checkFiles(files)...)
@@ -14,18 +47,10 @@ func PLC16(files map[string]string) []message.Message {
 	return messages
 }
 
-func checkFiles(files map[string]string) []message.Message {
-	var fileCodes = map[string]string{
-		".github/FUNDING.yml": "PLC16001",
-	}
-
-	return asserts.FileExists(files, fileCodes)
+func checkFiles(files repofs.RepoFS) []message.Message {
+	return asserts.FileExists(files, listFiles())
 }
 
-func checkFolders(files map[string]string) []message.Message {
-	var fileCodes = map[string]string{
-		".github/workflows/": "PLC16002",
-	}
-
-	return asserts.FolderExists(files, fileCodes)
+func checkFolders(files repofs.RepoFS) []message.Message {
+	return asserts.FolderExists(files, listFolders())
 }
@@ -3,6 +3,7 @@ package checks
 import (
 	"github.com/stretchr/testify/assert"
 	"internal/check"
+	"internal/repofs"
 	"testing"
 )
 
@@ -38,7 +39,7 @@ func TestPLC17(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			messages := PLC17(test.files, test.repo)
+			messages := PLC17(repofs.CreateFromMap(test.files), repofs.CreateFromMap(test.repo))
 
 			for _, message := range messages {
 				assert.Equal(t, test.status[message.Code], message.Status)
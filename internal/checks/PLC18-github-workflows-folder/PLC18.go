@@ -2,7 +2,10 @@ package checks
 
 import (
 	"internal/asserts"
+	"internal/fix"
 	"internal/message"
+	"internal/registry"
+	"internal/repofs"
 )
 
 func listCodes() map[string]string {
@@ -11,10 +14,20 @@ func listCodes() map[string]string {
 	}
 }
 
-func PLC18(files map[string]string) []message.Message {
-	var fileCodes = map[string]string{
+func listFiles() map[string]string {
+	return map[string]string{
 		".github/workflows/release.yml": "PLC18001",
 	}
+}
+
+func init() {
+	registry.Register(registry.CreateFixableCheck("PLC18", ".github/workflows folder", ".github/workflows/", listCodes(), func(checkContext registry.Context) []message.Message {
+		return PLC18(checkContext.Files)
+	}, func(checkContext registry.Context, messages []message.Message) []fix.Change {
+		return fix.CopyFromSkeleton(checkContext.Files, checkContext.Skeleton, listFiles(), messages)
+	}))
+}
 
-	return asserts.FileExists(files, fileCodes)
+func PLC18(files repofs.RepoFS) []message.Message {
+	return asserts.FileExists(files, listFiles())
 }
@@ -0,0 +1,49 @@
+package checks
+
+import (
+	"internal/asserts"
+	"internal/check"
+	"internal/fix"
+	"internal/message"
+	"internal/registry"
+	"internal/repofs"
+)
+
+const targetFolder = "examples/"
+
+func listCodes() map[string]string {
+	return map[string]string{
+		"PLC20001": "The repository MUST contain an `examples/` folder",
+		"PLC20002": "The `examples/` folder MUST contain at least one example file",
+	}
+}
+
+func init() {
+	registry.Register(registry.CreateFixableCheck("PLC20", "examples folder", targetFolder, listCodes(), func(checkContext registry.Context) []message.Message {
+		return PLC20(checkContext.Files)
+	}, func(checkContext registry.Context, messages []message.Message) []fix.Change {
+		return fix.CreateFolders(map[string]string{targetFolder: "PLC20001"}, messages)
+	}))
+}
+
+func PLC20(files repofs.RepoFS) []message.Message {
+	var messages []message.Message
+
+	codes := listCodes()
+
+	messages = append(messages, asserts.FolderExists(files, map[string]string{targetFolder: "PLC20001"})...)
+
+	status := check.Skip
+
+	if _, ok := files.Stat(targetFolder); ok {
+		status = check.Fail
+
+		if len(asserts.ListFolderFiles(files, targetFolder)) > 0 {
+			status = check.Pass
+		}
+	}
+
+	messages = append(messages, message.CreateMessage(status, "PLC20002", codes["PLC20002"]))
+
+	return messages
+}
@@ -0,0 +1,42 @@
+package checks
+
+import (
+	"github.com/stretchr/testify/assert"
+	"internal/check"
+	"internal/repofs"
+	"testing"
+)
+
+func TestPLC20(t *testing.T) {
+	tests := map[string]struct {
+		files  map[string]string
+		status map[string]check.Status
+	}{
+		"examples/ folder absent": {
+			files:  nil,
+			status: map[string]check.Status{"PLC20001": check.Fail, "PLC20002": check.Skip},
+		},
+		"examples/ folder present but empty": {
+			files:  map[string]string{"examples/": ""},
+			status: map[string]check.Status{"PLC20001": check.Pass, "PLC20002": check.Fail},
+		},
+		"examples/ folder present with only a gitkeep file and a sub-folder": {
+			files:  map[string]string{"examples/": "", "examples/.gitkeep": "", "examples/foo/": ""},
+			status: map[string]check.Status{"PLC20001": check.Pass, "PLC20002": check.Fail},
+		},
+		"examples/ folder present with an example file": {
+			files:  map[string]string{"examples/": "", "examples/gitlab-ci.yml": "mock content"},
+			status: map[string]check.Status{"PLC20001": check.Pass, "PLC20002": check.Pass},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			messages := PLC20(repofs.CreateFromMap(test.files))
+
+			for _, message := range messages {
+				assert.Equal(t, test.status[message.Code], message.Status)
+			}
+		})
+	}
+}
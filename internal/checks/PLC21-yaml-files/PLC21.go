@@ -0,0 +1,109 @@
+package checks
+
+import (
+	"fmt"
+	"internal/check"
+	"internal/message"
+	"internal/registry"
+	"internal/repofs"
+	"internal/yamllint"
+	"strings"
+)
+
+const configFile = ".yamllint"
+
+func listCodes() map[string]string {
+	return map[string]string{
+		"PLC21001": "The YAML files in the repository MUST pass the error level linting rules defined in `.yamllint`",
+		"PLC21002": "The YAML files in the repository SHOULD pass the warning level linting rules defined in `.yamllint`",
+	}
+}
+
+func init() {
+	registry.Register(registry.CreateCheck("PLC21", "YAML files", "", listCodes(), func(checkContext registry.Context) []message.Message {
+		return PLC21(checkContext.Files)
+	}))
+}
+
+func PLC21(files repofs.RepoFS) []message.Message {
+	var messages []message.Message
+
+	codes := listCodes()
+	reasons := map[string]string{}
+	status := map[string]check.Status{}
+
+	for code := range codes {
+		status[code] = check.Skip
+	}
+
+	if _, ok := files.Stat(configFile); ok {
+		var config yamllint.Config
+
+		content, err := files.ReadFile(configFile)
+
+		if err == nil {
+			config, err = yamllint.ParseConfig(content, files)
+		}
+
+		if err != nil {
+			reasons["PLC21001"] = fmt.Sprintf("The `%s` file could not be read: %s", configFile, err)
+			status["PLC21001"] = check.Error
+		} else {
+			var paths []string
+
+			for _, path := range files.List() {
+				if !strings.HasSuffix(path, "/") && config.IsYamlFile(path) {
+					paths = append(paths, path)
+				}
+			}
+
+			levelCodes := map[string]string{"error": "PLC21001", "warning": "PLC21002"}
+
+			for _, code := range levelCodes {
+				status[code] = check.Pass
+			}
+
+			// Report each problem on its own, so the file, line and rule are all shown
+			for _, path := range paths {
+				source, err := files.ReadFile(path)
+
+				if err != nil {
+					delete(status, "PLC21001")
+
+					messages = append(messages, message.CreateErrorMessage(
+						"PLC21001",
+						codes["PLC21001"],
+						fmt.Sprintf("The `%s` file could not be read: %s", path, err),
+					))
+
+					continue
+				}
+
+				for _, problem := range yamllint.Lint(path, []byte(source), config) {
+					code := levelCodes[problem.Level]
+
+					delete(status, code)
+
+					messages = append(messages, message.CreateLocatedMessage(
+						check.Fail,
+						code,
+						path,
+						problem.Line,
+						problem.Column,
+						fmt.Sprintf("%s (%s)", problem.Message, problem.Rule),
+					))
+				}
+			}
+		}
+	}
+
+	for code, checkStatus := range status {
+		if checkStatus == check.Error {
+			messages = append(messages, message.CreateErrorMessage(code, codes[code], reasons[code]))
+		} else {
+			messages = append(messages, message.CreateMessage(checkStatus, code, codes[code]))
+		}
+	}
+
+	return messages
+}
@@ -0,0 +1,80 @@
+package checks
+
+import (
+	"github.com/stretchr/testify/assert"
+	"internal/check"
+	"internal/repofs"
+	"testing"
+)
+
+func TestPLC21(t *testing.T) {
+	tests := map[string]struct {
+		files  map[string]string
+		status map[string]check.Status
+	}{
+		configFile + " file absent": {
+			files:  map[string]string{".gitlab-ci.yml": "key:  value"},
+			status: map[string]check.Status{"PLC21001": check.Skip, "PLC21002": check.Skip},
+		},
+		configFile + " file invalid": {
+			files:  map[string]string{configFile: "rules:\n  unknown: enable\n"},
+			status: map[string]check.Status{"PLC21001": check.Error, "PLC21002": check.Skip},
+		},
+		configFile + " file present, YAML files valid": {
+			files: map[string]string{
+				configFile:                    "---\nextends: default\n",
+				".gitlab-ci.yml":              "---\nstages:\n  - build\n",
+				".github/":                    "",
+				".github/workflows/":          "",
+				".github/workflows/main.yaml": "---\nname: main\n",
+				"README.md":                   "key:  value",
+			},
+			status: map[string]check.Status{"PLC21001": check.Pass, "PLC21002": check.Pass},
+		},
+		configFile + " file present, YAML files with errors": {
+			files: map[string]string{
+				configFile:       "---\nextends: default\n",
+				".gitlab-ci.yml": "---\nstages:\n  -  build\n",
+			},
+			status: map[string]check.Status{"PLC21001": check.Fail, "PLC21002": check.Pass},
+		},
+		configFile + " file present, YAML files with warnings": {
+			files: map[string]string{
+				configFile:   "---\nextends: default\n",
+				"action.yml": "name: action\n",
+			},
+			status: map[string]check.Status{"PLC21001": check.Pass, "PLC21002": check.Fail},
+		},
+		configFile + " file present, YAML files ignored": {
+			files: map[string]string{
+				configFile:   "---\nextends: default\nignore: action.yml\n",
+				"action.yml": "name:  action\n",
+			},
+			status: map[string]check.Status{"PLC21001": check.Pass, "PLC21002": check.Pass},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			messages := PLC21(repofs.CreateFromMap(test.files))
+
+			for _, message := range messages {
+				assert.Equal(t, test.status[message.Code], message.Status, "%s expected status %v, got %v", message.Code, test.status[message.Code], message.Status)
+			}
+		})
+	}
+}
+
+func TestPLC21Location(t *testing.T) {
+	messages := PLC21(repofs.CreateFromMap(map[string]string{
+		configFile:       "---\nextends: default\n",
+		".gitlab-ci.yml": "---\nstages:\n  -  build\n",
+	}))
+
+	for _, message := range messages {
+		if message.Status == check.Fail {
+			assert.Equal(t, ".gitlab-ci.yml", message.Path)
+			assert.Equal(t, 3, message.Line)
+		}
+	}
+}
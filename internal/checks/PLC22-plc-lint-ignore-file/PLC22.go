@@ -0,0 +1,104 @@
+package checks
+
+import (
+	"fmt"
+	"internal/check"
+	"internal/message"
+	"internal/registry"
+	"internal/repofs"
+	"internal/suppression"
+	"time"
+)
+
+func listCodes() map[string]string {
+	return map[string]string{
+		"PLC22001": "The `.plc-lint-ignore` file MUST only contain suppressions with a PLC code and a reason",
+		"PLC22002": "The suppressions in the `.plc-lint-ignore` file MUST NOT be expired",
+		"PLC22003": "The suppressions in the `.plc-lint-ignore` file SHOULD match a failed check",
+	}
+}
+
+func init() {
+	registry.Register(registry.CreateCheck("PLC22", "`.plc-lint-ignore` file", suppression.FileName, listCodes(), func(checkContext registry.Context) []message.Message {
+		return PLC22(checkContext.Files, registry.HasCode, time.Now())
+	}))
+}
+
+// PLC22 checks the suppressions themselves. Whether a suppression matches a
+// failed check (PLC22003) can only be known once all checks have run, so that
+// is reported when the suppressions are applied. A suppression of a code that
+// no check reports (as told by hasCode) fails PLC22001.
+func PLC22(files repofs.RepoFS, hasCode func(code string) bool, now time.Time) []message.Message {
+	var messages []message.Message
+
+	codes := listCodes()
+	reasons := map[string]string{}
+	status := map[string]check.Status{}
+
+	for _, code := range []string{"PLC22001", "PLC22002"} {
+		status[code] = check.Skip
+	}
+
+	if _, ok := files.Stat(suppression.FileName); ok {
+		if content, err := files.ReadFile(suppression.FileName); err != nil {
+			reasons["PLC22001"] = fmt.Sprintf("The `%s` file could not be read: %s", suppression.FileName, err)
+			status["PLC22001"] = check.Error
+		} else {
+			suppressions, problems := suppression.Parse(content)
+
+			status["PLC22001"] = check.Pass
+			status["PLC22002"] = check.Pass
+
+			for _, problem := range problems {
+				delete(status, "PLC22001")
+
+				messages = append(messages, message.CreateLocatedMessage(
+					check.Fail,
+					"PLC22001",
+					"",
+					problem.Line,
+					0,
+					problem.Message,
+				))
+			}
+
+			for _, entry := range suppressions {
+				if !hasCode(entry.Code) {
+					delete(status, "PLC22001")
+
+					messages = append(messages, message.CreateLocatedMessage(
+						check.Fail,
+						"PLC22001",
+						"",
+						entry.Line,
+						0,
+						fmt.Sprintf("'%s' is not the code of a check", entry.Code),
+					))
+				}
+
+				if entry.IsExpired(now) {
+					delete(status, "PLC22002")
+
+					messages = append(messages, message.CreateLocatedMessage(
+						check.Fail,
+						"PLC22002",
+						"",
+						entry.Line,
+						0,
+						fmt.Sprintf("The suppression of %s expired on %s", entry.Code, entry.Expires.Format(time.DateOnly)),
+					))
+				}
+			}
+		}
+	}
+
+	for code, checkStatus := range status {
+		if checkStatus == check.Error {
+			messages = append(messages, message.CreateErrorMessage(code, codes[code], reasons[code]))
+		} else {
+			messages = append(messages, message.CreateMessage(checkStatus, code, codes[code]))
+		}
+	}
+
+	return messages
+}
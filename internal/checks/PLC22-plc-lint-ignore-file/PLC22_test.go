@@ -0,0 +1,59 @@
+package checks
+
+import (
+	"github.com/stretchr/testify/assert"
+	"internal/check"
+	"internal/repofs"
+	"internal/suppression"
+	"testing"
+	"time"
+)
+
+func TestPLC22(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	hasCode := func(code string) bool {
+		return code != "PLC9999"
+	}
+
+	tests := map[string]struct {
+		files  map[string]string
+		status map[string]check.Status
+	}{
+		suppression.FileName + " file absent": {
+			files:  map[string]string{"README.md": ""},
+			status: map[string]check.Status{"PLC22001": check.Skip, "PLC22002": check.Skip},
+		},
+		suppression.FileName + " file present, suppressions valid": {
+			files:  map[string]string{suppression.FileName: "# Comment\nPLC6001 Reason\nPLC6002 2025-06-01 Reason\n"},
+			status: map[string]check.Status{"PLC22001": check.Pass, "PLC22002": check.Pass},
+		},
+		suppression.FileName + " file present, suppression without reason": {
+			files:  map[string]string{suppression.FileName: "PLC6001\n"},
+			status: map[string]check.Status{"PLC22001": check.Fail, "PLC22002": check.Pass},
+		},
+		suppression.FileName + " file present, suppression of an unknown code": {
+			files:  map[string]string{suppression.FileName: "PLC9999 Reason\n"},
+			status: map[string]check.Status{"PLC22001": check.Fail, "PLC22002": check.Pass},
+		},
+		suppression.FileName + " file present, suppression of a PLC22 code": {
+			files:  map[string]string{suppression.FileName: "PLC22003 Reason\n"},
+			status: map[string]check.Status{"PLC22001": check.Fail, "PLC22002": check.Pass},
+		},
+		suppression.FileName + " file present, suppression expired": {
+			files:  map[string]string{suppression.FileName: "PLC6001 2025-05-31 Reason\n"},
+			status: map[string]check.Status{"PLC22001": check.Pass, "PLC22002": check.Fail},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			messages := PLC22(repofs.CreateFromMap(test.files), hasCode, now)
+
+			assert.Len(t, messages, len(test.status))
+
+			for _, message := range messages {
+				assert.Equal(t, test.status[message.Code], message.Status, "%s expected status %v, got %v", message.Code, test.status[message.Code], message.Status)
+			}
+		})
+	}
+}
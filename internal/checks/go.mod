@@ -3,45 +3,72 @@ module checks
 go 1.22
 
 require (
+	github.com/ProtonMail/go-crypto v1.0.0
+	github.com/go-git/go-billy/v5 v5.5.0
+	github.com/go-git/go-git/v5 v5.12.0
 	github.com/gomarkdown/markdown v0.0.0-20240730141124-034f12af3bf6
 	github.com/stretchr/testify v1.9.0
+	gopkg.in/yaml.v3 v3.0.1
 	internal/asserts v0.1.0
 	internal/check v0.1.0
+	internal/directorylist v0.1.0
+	internal/dockerfile v0.1.0
+	internal/fix v0.1.0
+	internal/httpclient v0.1.0
+	internal/httpclienttest v0.1.0
+	internal/markdownlint v0.1.0
 	internal/message v0.1.0
+	internal/registry v0.1.0
+	internal/repofs v0.1.0
 	internal/repositorycontents v0.1.0
+	internal/suppression v0.1.0
+	internal/yamllint v0.1.0
 )
 
 require (
 	dario.cat/mergo v1.0.0 // indirect
 	github.com/Microsoft/go-winio v0.6.1 // indirect
-	github.com/ProtonMail/go-crypto v1.0.0 // indirect
 	github.com/cloudflare/circl v1.3.7 // indirect
+	github.com/containerd/typeurl/v2 v2.1.1 // indirect
 	github.com/cyphar/filepath-securejoin v0.2.4 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/emirpasic/gods v1.18.1 // indirect
 	github.com/go-git/gcfg v1.5.1-0.20230307220236-3a3c6141e376 // indirect
-	github.com/go-git/go-billy/v5 v5.5.0 // indirect
-	github.com/go-git/go-git/v5 v5.12.0 // indirect
+	github.com/gogo/protobuf v1.3.2 // indirect
 	github.com/golang/groupcache v0.0.0-20210331224755-41bb18bfe9da // indirect
 	github.com/jbenet/go-context v0.0.0-20150711004518-d14ea06fba99 // indirect
 	github.com/kevinburke/ssh_config v1.2.0 // indirect
+	github.com/moby/buildkit v0.13.2 // indirect
 	github.com/pjbgf/sha1cd v0.3.0 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/sergi/go-diff v1.3.2-0.20230802210424-5b0b94c5c0d3 // indirect
 	github.com/skeema/knownhosts v1.2.2 // indirect
 	github.com/xanzy/ssh-agent v0.3.3 // indirect
 	golang.org/x/crypto v0.21.0 // indirect
-	golang.org/x/mod v0.12.0 // indirect
-	golang.org/x/net v0.22.0 // indirect
+	golang.org/x/mod v0.13.0 // indirect
+	golang.org/x/net v0.23.0 // indirect
 	golang.org/x/sys v0.18.0 // indirect
-	golang.org/x/tools v0.13.0 // indirect
+	golang.org/x/tools v0.14.0 // indirect
+	google.golang.org/protobuf v1.31.0 // indirect
 	gopkg.in/warnings.v0 v0.1.2 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
+	internal/textdiff v0.1.0 // indirect
 )
 
 replace (
 	internal/asserts => ../asserts
 	internal/check => ../check
+	internal/directorylist => ../directorylist
+	internal/dockerfile => ../dockerfile
+	internal/fix => ../fix
+	internal/httpclient => ../httpclient
+	internal/httpclienttest => ../httpclienttest
+	internal/markdownlint => ../markdownlint
 	internal/message => ../message
+	internal/registry => ../registry
+	internal/repofs => ../repofs
 	internal/repositorycontents => ../repositorycontents
+	internal/suppression => ../suppression
+	internal/textdiff => ../textdiff
+	internal/yamllint => ../yamllint
 )
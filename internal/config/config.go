@@ -0,0 +1,305 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// FileName is the name of the configuration file in the root of a component
+const FileName = ".plc-lint.yml"
+
+var (
+	FailOnLevels = []string{"fail", "incomplete", "error"}
+	Formats      = []string{"gitlab-codequality", "json", "junit", "sarif", "text"}
+
+	codePattern = regexp.MustCompile(`^PLC\d{1,2}(\d{3})?$`)
+)
+
+type Markers struct {
+	Error      string `yaml:"error,omitempty"`
+	Fail       string `yaml:"fail,omitempty"`
+	Incomplete string `yaml:"incomplete,omitempty"`
+	Pass       string `yaml:"pass,omitempty"`
+	Skip       string `yaml:"skip,omitempty"`
+	Suppressed string `yaml:"suppressed,omitempty"`
+}
+
+// Config holds the settings that can be made in a configuration file. Codes
+// in Disable and Enable can either be a single code (`PLC12003`) or a whole
+// family (`PLC12`).
+type Config struct {
+	// CacheTTL is how long a cached skeleton repository is used, before it is
+	// fetched again, as a duration like "24h" or "30m".
+	CacheTTL string `yaml:"cache-ttl,omitempty"`
+	// CheckTimeout is how long a single check may run, before it is reported
+	// as incomplete. Zero means no limit.
+	CheckTimeout string   `yaml:"check-timeout,omitempty"`
+	Disable      []string `yaml:"disable,omitempty"`
+	Enable       []string `yaml:"enable,omitempty"`
+	// Exclude holds patterns, in `.gitignore` syntax, for paths in the working
+	// tree that are left out of the checks
+	Exclude []string `yaml:"exclude,omitempty"`
+	FailOn  string   `yaml:"fail-on,omitempty"`
+	Format  string   `yaml:"format,omitempty"`
+	// HTTPTimeout is how long a single HTTP request made by a check may take
+	HTTPTimeout string  `yaml:"http-timeout,omitempty"`
+	Markers     Markers `yaml:"markers"`
+	// Proxy is the URL of the proxy HTTP requests are sent through. When it is
+	// not set, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY variables are used.
+	Proxy string `yaml:"proxy,omitempty"`
+	// RequireSignedCommits makes unsigned commits fail PLC3006. It is a
+	// pointer, so a file can turn off what a file below it turned on.
+	RequireSignedCommits *bool  `yaml:"require-signed-commits,omitempty"`
+	Skeleton             string `yaml:"skeleton,omitempty"`
+	// SkeletonRef pins the skeleton repository to a branch, tag or commit
+	SkeletonRef string `yaml:"skeleton-ref,omitempty"`
+	// Timeout is how long all checks together may run. Zero means no limit.
+	Timeout string `yaml:"timeout,omitempty"`
+	// URLCacheTTL is how long the result of a URL that resolves is used,
+	// before the URL is checked again. Zero means URL results are not cached.
+	URLCacheTTL string `yaml:"url-cache-ttl,omitempty"`
+}
+
+// Default returns the configuration that is used when nothing else is set
+func Default() Config {
+	return Config{
+		CacheTTL:     "24h",
+		CheckTimeout: "1m",
+		FailOn:       "fail",
+		Format:       "text",
+		HTTPTimeout:  "10s",
+		Markers: Markers{
+			Error:      "💥",
+			Fail:       "❌",
+			Incomplete: "⚠️",
+			Pass:       "✅",
+			Skip:       "⏭ ",
+			Suppressed: "🔇",
+		},
+		Skeleton:    "https://gitlab.com/pipeline-components/org/skeleton.git",
+		Timeout:     "5m",
+		URLCacheTTL: "24h",
+	}
+}
+
+func getFamily(code string) string {
+	family := code
+
+	if matches := codePattern.FindStringSubmatch(code); matches != nil && matches[1] != "" {
+		family = strings.TrimSuffix(code, matches[1])
+	}
+
+	return family
+}
+
+// IsRemote tells whether the given skeleton source is a repository URL,
+// rather than a path on disk.
+func IsRemote(skeleton string) bool {
+	return strings.Contains(skeleton, "://") || strings.HasPrefix(skeleton, "git@")
+}
+
+// Paths returns the locations a configuration file is looked for, from the
+// lowest to the highest precedence: the user config dir, then the component.
+func Paths(projectPath string) []string {
+	var paths []string
+
+	if userConfigDir, err := os.UserConfigDir(); err == nil {
+		paths = append(paths, filepath.Join(userConfigDir, "plc-lint", "config.yml"))
+	}
+
+	return append(paths, filepath.Join(projectPath, FileName))
+}
+
+// Load merges the configuration files at the given paths (that exist) on top
+// of the default configuration. The paths of the files that were loaded are
+// returned, so it is clear where the configuration came from.
+func Load(paths []string) (Config, []string, error) {
+	var loaded []string
+
+	config := Default()
+
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return config, loaded, fmt.Errorf("could not read configuration file '%s': %w", path, err)
+		}
+
+		fileConfig, err := Parse(content)
+
+		if err != nil {
+			return config, loaded, fmt.Errorf("invalid configuration file '%s': %w", path, err)
+		}
+
+		// A relative skeleton path is relative to the file it is set in
+		if fileConfig.Skeleton != "" && !IsRemote(fileConfig.Skeleton) && !filepath.IsAbs(fileConfig.Skeleton) {
+			fileConfig.Skeleton = filepath.Join(filepath.Dir(path), fileConfig.Skeleton)
+		}
+
+		config = config.Merge(fileConfig)
+		loaded = append(loaded, path)
+	}
+
+	return config, loaded, nil
+}
+
+// Parse reads a configuration from the given YAML content. Unknown keys are
+// not allowed, so typos do not go unnoticed.
+func Parse(content []byte) (Config, error) {
+	var config Config
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return config, err
+	}
+
+	return config, config.Validate()
+}
+
+// IsEnabled tells whether the given code should be reported. A code that is
+// listed on its own takes precedence over the family it belongs to, so a
+// family can be disabled with only some of its codes enabled, or the other
+// way around.
+func (c Config) IsEnabled(code string) bool {
+	enabled := true
+
+	if slices.Contains(c.Disable, code) {
+		enabled = false
+	} else if slices.Contains(c.Enable, code) {
+		enabled = true
+	} else if slices.Contains(c.Disable, getFamily(code)) {
+		enabled = false
+	}
+
+	return enabled
+}
+
+// SignedCommitsRequired tells whether commits must be signed, which they do
+// not need to be unless it is turned on.
+func (c Config) SignedCommitsRequired() bool {
+	return c.RequireSignedCommits != nil && *c.RequireSignedCommits
+}
+
+// Marshal renders the configuration as YAML, in the same shape as it is read
+func (c Config) Marshal() ([]byte, error) {
+	return yaml.Marshal(c)
+}
+
+// Merge returns the configuration with the values set in the given
+// configuration on top. Codes that are disabled or enabled are combined.
+func (c Config) Merge(other Config) Config {
+	merged := c
+
+	merged.Disable = mergeLists(c.Disable, other.Disable)
+	merged.Enable = mergeLists(c.Enable, other.Enable)
+	merged.Exclude = mergeLists(c.Exclude, other.Exclude)
+
+	// An explicit entry in the other configuration overrides the opposite entry
+	merged.Disable = slices.DeleteFunc(merged.Disable, func(code string) bool {
+		return slices.Contains(other.Enable, code)
+	})
+	merged.Enable = slices.DeleteFunc(merged.Enable, func(code string) bool {
+		return slices.Contains(other.Disable, code)
+	})
+
+	merged.CacheTTL = override(c.CacheTTL, other.CacheTTL)
+	merged.CheckTimeout = override(c.CheckTimeout, other.CheckTimeout)
+	merged.FailOn = override(c.FailOn, other.FailOn)
+	merged.Format = override(c.Format, other.Format)
+	merged.HTTPTimeout = override(c.HTTPTimeout, other.HTTPTimeout)
+	merged.Markers.Error = override(c.Markers.Error, other.Markers.Error)
+	merged.Markers.Fail = override(c.Markers.Fail, other.Markers.Fail)
+	merged.Markers.Incomplete = override(c.Markers.Incomplete, other.Markers.Incomplete)
+	merged.Markers.Pass = override(c.Markers.Pass, other.Markers.Pass)
+	merged.Markers.Skip = override(c.Markers.Skip, other.Markers.Skip)
+	merged.Markers.Suppressed = override(c.Markers.Suppressed, other.Markers.Suppressed)
+	merged.Proxy = override(c.Proxy, other.Proxy)
+
+	if other.RequireSignedCommits != nil {
+		merged.RequireSignedCommits = other.RequireSignedCommits
+	}
+
+	merged.Skeleton = override(c.Skeleton, other.Skeleton)
+	merged.SkeletonRef = override(c.SkeletonRef, other.SkeletonRef)
+	merged.Timeout = override(c.Timeout, other.Timeout)
+	merged.URLCacheTTL = override(c.URLCacheTTL, other.URLCacheTTL)
+
+	return merged
+}
+
+// Validate checks all values in the configuration, and reports every problem
+// that is found, rather than only the first.
+func (c Config) Validate() error {
+	var problems []error
+
+	for key, value := range map[string]string{"cache-ttl": c.CacheTTL, "check-timeout": c.CheckTimeout, "http-timeout": c.HTTPTimeout, "timeout": c.Timeout, "url-cache-ttl": c.URLCacheTTL} {
+		if duration, err := time.ParseDuration(value); value != "" && (err != nil || duration < 0) {
+			problems = append(problems, fmt.Errorf("invalid %s '%s', expected a duration like 24h or 30m", key, value))
+		}
+	}
+
+	for _, pattern := range c.Exclude {
+		if _, err := path.Match(pattern, ""); err != nil {
+			problems = append(problems, fmt.Errorf("invalid exclude pattern '%s': %v", pattern, err))
+		}
+	}
+
+	if c.FailOn != "" && !slices.Contains(FailOnLevels, c.FailOn) {
+		problems = append(problems, fmt.Errorf("unsupported fail-on level '%s', expected one of: %s", c.FailOn, strings.Join(FailOnLevels, ", ")))
+	}
+
+	if c.Format != "" && !slices.Contains(Formats, c.Format) {
+		problems = append(problems, fmt.Errorf("unsupported format '%s', expected one of: %s", c.Format, strings.Join(Formats, ", ")))
+	}
+
+	for key, codes := range map[string][]string{"disable": c.Disable, "enable": c.Enable} {
+		for _, code := range codes {
+			if !codePattern.MatchString(code) {
+				problems = append(problems, fmt.Errorf("'%s' in %s is not a PLC code (like PLC12003) or family (like PLC12)", code, key))
+			} else if key == "enable" && slices.Contains(c.Disable, code) {
+				problems = append(problems, fmt.Errorf("'%s' is both enabled and disabled", code))
+			}
+		}
+	}
+
+	slices.SortFunc(problems, func(a, b error) int {
+		return strings.Compare(a.Error(), b.Error())
+	})
+
+	return errors.Join(problems...)
+}
+
+func mergeLists(values []string, others []string) []string {
+	var merged []string
+
+	for _, value := range append(slices.Clone(values), others...) {
+		if !slices.Contains(merged, value) {
+			merged = append(merged, value)
+		}
+	}
+
+	return merged
+}
+
+// override returns the given value, unless it is empty
+func override(current string, value string) string {
+	if value != "" {
+		current = value
+	}
+
+	return current
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	required := true
+
+	tests := map[string]struct {
+		content  string
+		error    string
+		expected Config
+	}{
+		"Empty file": {
+			content:  "",
+			expected: Config{},
+		},
+		"All settings": {
+			content: "cache-ttl: 1h\ncheck-timeout: 30s\ndisable: [PLC12, PLC13001]\nenable: [PLC12003]\nexclude: [vendor/]\nfail-on: error\nformat: json\nhttp-timeout: 5s\nmarkers:\n  pass: OK\nproxy: http://proxy.example.com:3128\nrequire-signed-commits: true\nskeleton: ../skeleton\nskeleton-ref: v1.0.0\ntimeout: 2m\nurl-cache-ttl: 12h\n",
+			expected: Config{
+				CacheTTL:             "1h",
+				CheckTimeout:         "30s",
+				Disable:              []string{"PLC12", "PLC13001"},
+				Enable:               []string{"PLC12003"},
+				Exclude:              []string{"vendor/"},
+				FailOn:               "error",
+				Format:               "json",
+				HTTPTimeout:          "5s",
+				Markers:              Markers{Pass: "OK"},
+				Proxy:                "http://proxy.example.com:3128",
+				RequireSignedCommits: &required,
+				Skeleton:             "../skeleton",
+				SkeletonRef:          "v1.0.0",
+				Timeout:              "2m",
+				URLCacheTTL:          "12h",
+			},
+		},
+		"Unknown key": {
+			content: "formats: json\n",
+			error:   "field formats not found",
+		},
+		"Invalid values": {
+			content: "cache-ttl: -1h\ncheck-timeout: soon\ndisable: [README]\nenable: [PLC1]\nexclude: ['[']\nfail-on: never\nformat: html\nhttp-timeout: 0.5\nurl-cache-ttl: week\n",
+			error:   "'README' in disable is not a PLC code (like PLC12003) or family (like PLC12)\ninvalid cache-ttl '-1h', expected a duration like 24h or 30m\ninvalid check-timeout 'soon', expected a duration like 24h or 30m\ninvalid exclude pattern '[': syntax error in pattern\ninvalid http-timeout '0.5', expected a duration like 24h or 30m\ninvalid url-cache-ttl 'week', expected a duration like 24h or 30m\nunsupported fail-on level 'never', expected one of: fail, incomplete, error\nunsupported format 'html', expected one of: gitlab-codequality, json, junit, sarif, text",
+		},
+		"Enabled and disabled": {
+			content: "disable: [PLC1]\nenable: [PLC1]\n",
+			error:   "'PLC1' is both enabled and disabled",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := Parse([]byte(test.content))
+
+			if test.error != "" {
+				assert.ErrorContains(t, err, test.error)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIsEnabled(t *testing.T) {
+	config := Config{
+		Disable: []string{"PLC12", "PLC13001"},
+		Enable:  []string{"PLC12003"},
+	}
+
+	tests := map[string]bool{
+		"PLC1001":  true,
+		"PLC12001": false,
+		"PLC12003": true,
+		"PLC13001": false,
+		"PLC13002": true,
+	}
+
+	for code, expected := range tests {
+		actual := config.IsEnabled(code)
+
+		assert.Equal(t, expected, actual, "%s expected enabled %v, got %v", code, expected, actual)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	base := Default()
+	base.Disable = []string{"PLC12", "PLC13"}
+	base.Exclude = []string{"vendor/"}
+
+	actual := base.Merge(Config{
+		Enable:  []string{"PLC13"},
+		Exclude: []string{"node_modules/", "vendor/"},
+		Format:  "json",
+		Markers: Markers{Fail: "FAIL"},
+	})
+
+	assert.Equal(t, []string{"PLC12"}, actual.Disable)
+	assert.Equal(t, []string{"PLC13"}, actual.Enable)
+	assert.Equal(t, []string{"vendor/", "node_modules/"}, actual.Exclude)
+	assert.Equal(t, "fail", actual.FailOn)
+	assert.Equal(t, "json", actual.Format)
+	assert.Equal(t, "FAIL", actual.Markers.Fail)
+	assert.Equal(t, "✅", actual.Markers.Pass)
+}
+
+func TestSignedCommitsRequired(t *testing.T) {
+	required, notRequired := true, false
+	userConfig := Default().Merge(Config{RequireSignedCommits: &required})
+
+	assert.False(t, Default().SignedCommitsRequired())
+	assert.True(t, userConfig.SignedCommitsRequired())
+	assert.True(t, userConfig.Merge(Config{}).SignedCommitsRequired())
+	assert.False(t, userConfig.Merge(Config{RequireSignedCommits: &notRequired}).SignedCommitsRequired())
+}
+
+func TestLoad(t *testing.T) {
+	directory := t.TempDir()
+	userFile := filepath.Join(directory, "user.yml")
+	componentFile := filepath.Join(directory, "component", FileName)
+
+	assert.NoError(t, os.MkdirAll(filepath.Dir(componentFile), 0o755))
+	assert.NoError(t, os.WriteFile(userFile, []byte("format: junit\nskeleton: https://example.com/skeleton.git\n"), 0o644))
+	assert.NoError(t, os.WriteFile(componentFile, []byte("format: sarif\nskeleton: ../skeleton\n"), 0o644))
+
+	actual, loaded, err := Load([]string{userFile, filepath.Join(directory, "missing.yml"), componentFile})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{userFile, componentFile}, loaded)
+	assert.Equal(t, "sarif", actual.Format)
+	assert.Equal(t, filepath.Join(directory, "skeleton"), actual.Skeleton)
+}
+
+func TestLoadInvalid(t *testing.T) {
+	file := filepath.Join(t.TempDir(), FileName)
+
+	assert.NoError(t, os.WriteFile(file, []byte("format: html\n"), 0o644))
+
+	_, _, err := Load([]string{file})
+
+	assert.ErrorContains(t, err, "invalid configuration file '"+file+"': unsupported format 'html'")
+}
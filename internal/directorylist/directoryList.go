@@ -1,50 +1,173 @@
 package directorylist
 
 import (
-	"fmt"
+	"bufio"
+	"bytes"
+	"errors"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
+	"strings"
 )
 
-func ListContent(root string, path string) ([]string, error) {
-	var (
-		dirEntries []os.DirEntry
-		err        error
-		files      []string
-	)
+const (
+	gitDir          = ".git"
+	gitignoreFile   = ".gitignore"
+	infoExcludeFile = ".git/info/exclude"
+)
+
+// Options changes what is listed. The zero value leaves out the `.git/`
+// directory and everything that git ignores.
+type Options struct {
+	// Exclude holds patterns, in `.gitignore` syntax, for paths to leave out
+	Exclude []string
+	// IncludeGitDir lists the `.git/` directory (and its content) as well
+	IncludeGitDir bool
+	// IncludeIgnored lists the paths that are ignored by the `.gitignore`
+	// files and `.git/info/exclude` as well
+	IncludeIgnored bool
+}
 
-	path = filepath.Clean(path)
-	dirPath := filepath.Join(root, path)
-	dirEntries, err = os.ReadDir(dirPath)
+// Listing holds the paths that were found. Directories end in a slash.
+type Listing struct {
+	// Errors holds the problems that were found, by the path they were found
+	// for, so one unreadable directory does not stop the rest being listed
+	Errors map[string]error
+	Paths  []string
+	// Symlinks holds the symbolic links that were found, they are not followed
+	Symlinks []string
+}
 
-	if err == nil {
-		for _, file := range dirEntries {
-			var pathName string
+// Err combines the errors in the listing, in the order of their paths. Nil is
+// returned when there are none.
+func (l Listing) Err() error {
+	var problems []error
 
-			dirMarker := ""
-			if file.IsDir() {
-				dirMarker = "/"
-			}
+	paths := make([]string, 0, len(l.Errors))
 
-			if path == "" || path == "." {
-				pathName = fmt.Sprintf("%s%s", file.Name(), dirMarker)
-			} else {
-				pathName = fmt.Sprintf("%s/%s%s", path, file.Name(), dirMarker)
-			}
+	for name := range l.Errors {
+		paths = append(paths, name)
+	}
+
+	slices.Sort(paths)
+
+	for _, name := range paths {
+		problems = append(problems, l.Errors[name])
+	}
+
+	return errors.Join(problems...)
+}
 
-			if file.IsDir() {
-				var fileNames []string
+type walker struct {
+	exclude gitignore.Matcher
+	listing Listing
+	options Options
+	root    string
+}
+
+// List walks the given directory, and lists the paths in it relative to it.
+func List(root string, options Options) Listing {
+	var patterns []gitignore.Pattern
+
+	for _, pattern := range options.Exclude {
+		patterns = append(patterns, gitignore.ParsePattern(pattern, nil))
+	}
+
+	w := &walker{
+		exclude: gitignore.NewMatcher(patterns),
+		listing: Listing{Errors: map[string]error{}},
+		options: options,
+		root:    root,
+	}
 
-				fileNames, err = ListContent(root, pathName)
+	var ignored []gitignore.Pattern
+
+	if !options.IncludeIgnored {
+		ignored = w.readPatterns(infoExcludeFile, nil)
+	}
+
+	w.walk(nil, ignored)
+
+	slices.Sort(w.listing.Paths)
+	slices.Sort(w.listing.Symlinks)
+
+	return w.listing
+}
 
-				if err == nil {
-					files = append(files, fileNames...)
-				}
+// readPatterns reads the patterns from the given ignore file. A missing file
+// has no patterns.
+func (w *walker) readPatterns(name string, domain []string) []gitignore.Pattern {
+	var patterns []gitignore.Pattern
+
+	content, err := os.ReadFile(filepath.Join(w.root, filepath.Join(domain...), filepath.FromSlash(name)))
+
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			w.listing.Errors[path.Join(path.Join(domain...), name)] = err
+		}
+	} else {
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+
+		for scanner.Scan() {
+			line := scanner.Text()
+
+			if !strings.HasPrefix(line, "#") && strings.TrimSpace(line) != "" {
+				patterns = append(patterns, gitignore.ParsePattern(line, domain))
 			}
+		}
+	}
+
+	return patterns
+}
+
+// walk lists the content of the given directory, and of the directories in
+// it, leaving out what is ignored or excluded.
+func (w *walker) walk(directory []string, ignored []gitignore.Pattern) {
+	if !w.options.IncludeIgnored {
+		ignored = append(slices.Clone(ignored), w.readPatterns(gitignoreFile, directory)...)
+	}
+
+	matcher := gitignore.NewMatcher(ignored)
+	dirPath := filepath.Join(w.root, filepath.Join(directory...))
 
-			files = append(files, pathName)
+	// Whatever could be read before an error is still listed
+	dirEntries, err := os.ReadDir(dirPath)
+
+	if err != nil {
+		name := "."
+
+		if len(directory) > 0 {
+			name = path.Join(directory...) + "/"
 		}
+
+		w.listing.Errors[name] = err
 	}
 
-	return files, err
+	for _, dirEntry := range dirEntries {
+		entryPath := append(slices.Clone(directory), dirEntry.Name())
+		isSymlink := dirEntry.Type()&fs.ModeSymlink != 0
+		isDir := dirEntry.IsDir() && !isSymlink
+
+		if dirEntry.Name() == gitDir && !w.options.IncludeGitDir {
+			continue
+		}
+
+		if w.exclude.Match(entryPath, isDir) || matcher.Match(entryPath, isDir) {
+			continue
+		}
+
+		name := path.Join(entryPath...)
+
+		if isSymlink {
+			w.listing.Symlinks = append(w.listing.Symlinks, name)
+		} else if isDir {
+			w.listing.Paths = append(w.listing.Paths, name+"/")
+			w.walk(entryPath, ignored)
+		} else {
+			w.listing.Paths = append(w.listing.Paths, name)
+		}
+	}
 }
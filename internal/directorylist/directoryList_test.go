@@ -0,0 +1,139 @@
+package directorylist
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func createFixture(t *testing.T, files map[string]string) string {
+	root := t.TempDir()
+
+	for name, content := range files {
+		fullPath := filepath.Join(root, filepath.FromSlash(name))
+
+		assert.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		assert.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+	}
+
+	return root
+}
+
+func TestList(t *testing.T) {
+	mockFiles := map[string]string{
+		".git/HEAD":                "ref: refs/heads/main\n",
+		".git/info/exclude":        "*.tmp\n",
+		".gitignore":               "# Mock comment\nnode_modules/\n*.log\n",
+		"README.md":                "mock content",
+		"app/.gitignore":           "!keep.log\n",
+		"app/debug.log":            "mock content",
+		"app/keep.log":             "mock content",
+		"debug.log":                "mock content",
+		"node_modules/foo/foo.js":  "mock content",
+		"scratch.tmp":              "mock content",
+		"vendor/bar/bar.go":        "mock content",
+		"vendor/bar/bar_test.go":   "mock content",
+		"examples/gitlab-ci.yml":   "mock content",
+		"examples/nested/.gitkeep": "",
+	}
+
+	tests := map[string]struct {
+		expected []string
+		options  Options
+	}{
+		"Ignored files and the .git directory are left out": {
+			expected: []string{
+				".gitignore",
+				"README.md",
+				"app/",
+				"app/.gitignore",
+				"app/keep.log",
+				"examples/",
+				"examples/gitlab-ci.yml",
+				"examples/nested/",
+				"examples/nested/.gitkeep",
+				"vendor/",
+				"vendor/bar/",
+				"vendor/bar/bar.go",
+				"vendor/bar/bar_test.go",
+			},
+		},
+		"Excluded paths are left out": {
+			expected: []string{
+				".gitignore",
+				"README.md",
+				"app/",
+				"app/.gitignore",
+				"app/keep.log",
+				"examples/",
+				"examples/gitlab-ci.yml",
+			},
+			options: Options{Exclude: []string{"vendor/", "nested"}},
+		},
+		"Ignored files and the .git directory are included": {
+			expected: []string{
+				".git/",
+				".git/HEAD",
+				".git/info/",
+				".git/info/exclude",
+				".gitignore",
+				"README.md",
+				"app/",
+				"app/.gitignore",
+				"app/debug.log",
+				"app/keep.log",
+				"debug.log",
+				"examples/",
+				"examples/gitlab-ci.yml",
+				"node_modules/",
+				"node_modules/foo/",
+				"node_modules/foo/foo.js",
+				"scratch.tmp",
+			},
+			options: Options{Exclude: []string{"vendor/", "examples/nested/"}, IncludeGitDir: true, IncludeIgnored: true},
+		},
+	}
+
+	root := createFixture(t, mockFiles)
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			listing := List(root, test.options)
+
+			assert.Equal(t, test.expected, listing.Paths)
+			assert.Empty(t, listing.Errors)
+			assert.NoError(t, listing.Err())
+		})
+	}
+}
+
+func TestListSymlinks(t *testing.T) {
+	root := createFixture(t, map[string]string{"README.md": "mock content", "app/main.go": "mock content"})
+	outside := createFixture(t, map[string]string{"secret.txt": "mock content"})
+
+	assert.NoError(t, os.Symlink(filepath.Join(root, "README.md"), filepath.Join(root, "link.md")))
+	assert.NoError(t, os.Symlink(outside, filepath.Join(root, "app", "outside")))
+
+	listing := List(root, Options{})
+
+	assert.Equal(t, []string{"README.md", "app/", "app/main.go"}, listing.Paths)
+	assert.Equal(t, []string{"app/outside", "link.md"}, listing.Symlinks)
+}
+
+func TestListErrors(t *testing.T) {
+	root := createFixture(t, map[string]string{"README.md": "mock content", "app/.gitignore/foo": "mock content"})
+
+	listing := List(root, Options{})
+
+	// The rest of the directory is still listed
+	assert.Equal(t, []string{"README.md", "app/", "app/.gitignore/", "app/.gitignore/foo"}, listing.Paths)
+	assert.Len(t, listing.Errors, 1)
+	assert.Contains(t, listing.Errors, "app/.gitignore")
+	assert.ErrorContains(t, listing.Err(), "is a directory")
+
+	listing = List(filepath.Join(root, "missing"), Options{})
+
+	assert.Empty(t, listing.Paths)
+	assert.Contains(t, listing.Errors, ".")
+}
@@ -0,0 +1,211 @@
+package dockerfile
+
+import (
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/moby/buildkit/frontend/dockerfile/shell"
+	"strings"
+)
+
+type Instruction struct {
+	Arguments []string
+	Command   string
+	EndLine   int
+	Flags     []string
+	Line      int
+	Original  string
+	// Variables (ARG and ENV) in scope when the instruction is reached
+	variables map[string]string
+}
+
+type Stage struct {
+	BaseImage    string
+	Instructions []Instruction
+	Line         int
+	Name         string
+}
+
+type Dockerfile struct {
+	escapeToken rune
+	MetaArgs    map[string]string
+	Stages      []Stage
+}
+
+type Variable struct {
+	Line  int
+	Name  string
+	Value string
+}
+
+// Expand replaces variables in a word with the values that are in scope for the
+// given instruction, the same way `docker build` would. Quotes are removed.
+func (d Dockerfile) Expand(instruction Instruction, word string) string {
+	lexer := shell.NewLex(d.escapeToken)
+
+	expanded, err := lexer.ProcessWordWithMap(word, instruction.variables)
+
+	if err != nil {
+		expanded = word
+	}
+
+	return expanded
+}
+
+// Env returns all declarations of the given environment variable, with the
+// value expanded, in the order they are declared.
+func (d Dockerfile) Env(name string) []Variable {
+	var variables []Variable
+
+	for _, stage := range d.Stages {
+		variables = append(variables, d.StageEnv(stage, name)...)
+	}
+
+	return variables
+}
+
+// StageEnv returns the declarations of the given environment variable in the
+// given stage only, with the value expanded, in the order they are declared.
+func (d Dockerfile) StageEnv(stage Stage, name string) []Variable {
+	var variables []Variable
+
+	for _, instruction := range stage.Instructions {
+		if instruction.Command == "ENV" {
+			for index := 0; index+1 < len(instruction.Arguments); index += 2 {
+				if instruction.Arguments[index] == name {
+					variables = append(variables, Variable{
+						Line:  instruction.Line,
+						Name:  name,
+						Value: d.Expand(instruction, instruction.Arguments[index+1]),
+					})
+				}
+			}
+		}
+	}
+
+	return variables
+}
+
+// FinalStage returns the stage that produces the resulting image
+func (d Dockerfile) FinalStage() (Stage, bool) {
+	var (
+		found bool
+		stage Stage
+	)
+
+	if len(d.Stages) > 0 {
+		found = true
+		stage = d.Stages[len(d.Stages)-1]
+	}
+
+	return stage, found
+}
+
+func copyVariables(variables map[string]string) map[string]string {
+	result := make(map[string]string, len(variables))
+
+	for key, value := range variables {
+		result[key] = value
+	}
+
+	return result
+}
+
+func createInstruction(node *parser.Node, variables map[string]string) Instruction {
+	var arguments []string
+
+	for next := node.Next; next != nil; next = next.Next {
+		arguments = append(arguments, next.Value)
+	}
+
+	return Instruction{
+		Arguments: arguments,
+		Command:   strings.ToUpper(node.Value),
+		EndLine:   node.EndLine,
+		Flags:     node.Flags,
+		Line:      node.StartLine,
+		Original:  node.Original,
+		variables: copyVariables(variables),
+	}
+}
+
+func Parse(content string) (Dockerfile, error) {
+	var (
+		err    error
+		result *parser.Result
+	)
+
+	dockerfile := Dockerfile{
+		escapeToken: '\\',
+		MetaArgs:    map[string]string{},
+	}
+
+	result, err = parser.Parse(strings.NewReader(content))
+
+	if err == nil {
+		dockerfile.escapeToken = result.EscapeToken
+
+		variables := map[string]string{}
+
+		for _, node := range result.AST.Children {
+			if strings.EqualFold(node.Value, "FROM") {
+				// Only meta ARGs are in scope for a FROM instruction
+				instruction := createInstruction(node, dockerfile.MetaArgs)
+				stage := Stage{Line: instruction.Line}
+
+				if len(instruction.Arguments) > 0 {
+					stage.BaseImage = dockerfile.Expand(instruction, instruction.Arguments[0])
+
+					// A base image that expands to nothing is reported as it is written
+					if stage.BaseImage == "" {
+						stage.BaseImage = instruction.Arguments[0]
+					}
+				}
+
+				if len(instruction.Arguments) > 2 && strings.EqualFold(instruction.Arguments[1], "AS") {
+					stage.Name = instruction.Arguments[2]
+				}
+
+				dockerfile.Stages = append(dockerfile.Stages, stage)
+
+				// Inside a stage, only meta ARGs that are declared again are in scope
+				variables = map[string]string{}
+
+				continue
+			}
+
+			instruction := createInstruction(node, variables)
+
+			switch instruction.Command {
+			case "ARG":
+				for _, argument := range instruction.Arguments {
+					name, value, hasValue := strings.Cut(argument, "=")
+
+					if hasValue {
+						value = dockerfile.Expand(instruction, value)
+					} else if len(dockerfile.Stages) > 0 {
+						value, hasValue = dockerfile.MetaArgs[name]
+					}
+
+					if len(dockerfile.Stages) == 0 {
+						dockerfile.MetaArgs[name] = value
+					}
+
+					if hasValue {
+						variables[name] = value
+					}
+				}
+
+			case "ENV":
+				for index := 0; index+1 < len(instruction.Arguments); index += 2 {
+					variables[instruction.Arguments[index]] = dockerfile.Expand(instruction, instruction.Arguments[index+1])
+				}
+			}
+
+			if len(dockerfile.Stages) > 0 {
+				stage := &dockerfile.Stages[len(dockerfile.Stages)-1]
+				stage.Instructions = append(stage.Instructions, instruction)
+			}
+		}
+	}
+
+	return dockerfile, err
+}
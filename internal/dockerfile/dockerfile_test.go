@@ -0,0 +1,105 @@
+package dockerfile
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		content    string
+		assertions func(Dockerfile, error)
+	}{
+		"Parse should complain when the Dockerfile is invalid": {
+			content: "ENV DEFAULTCMD",
+			assertions: func(dockerfile Dockerfile, err error) {
+				assert.NotNil(t, err)
+				assert.Len(t, dockerfile.Stages, 0)
+			},
+		},
+		"Parse should complain when the Dockerfile has no instructions": {
+			content: "# Mock comment\n",
+			assertions: func(dockerfile Dockerfile, err error) {
+				assert.NotNil(t, err)
+
+				_, found := dockerfile.FinalStage()
+				assert.False(t, found)
+			},
+		},
+		"Parse should return stages with expanded base images": {
+			content: "ARG VERSION=3.19\nFROM alpine:${VERSION} AS build\nRUN true\n\nFROM scratch\nCOPY --from=build / /\n",
+			assertions: func(dockerfile Dockerfile, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, map[string]string{"VERSION": "3.19"}, dockerfile.MetaArgs)
+				assert.Len(t, dockerfile.Stages, 2)
+
+				assert.Equal(t, "alpine:3.19", dockerfile.Stages[0].BaseImage)
+				assert.Equal(t, "build", dockerfile.Stages[0].Name)
+				assert.Equal(t, 2, dockerfile.Stages[0].Line)
+				assert.Len(t, dockerfile.Stages[0].Instructions, 1)
+
+				stage, found := dockerfile.FinalStage()
+				assert.True(t, found)
+				assert.Equal(t, "scratch", stage.BaseImage)
+				assert.Equal(t, "COPY", stage.Instructions[0].Command)
+				assert.Equal(t, []string{"--from=build"}, stage.Instructions[0].Flags)
+				assert.Equal(t, 6, stage.Instructions[0].Line)
+			},
+		},
+		"Parse should keep base images that expand to nothing as they are written": {
+			content: "FROM ${UNDEFINED}\n",
+			assertions: func(dockerfile Dockerfile, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, "${UNDEFINED}", dockerfile.Stages[0].BaseImage)
+			},
+		},
+		"Env should return multi-line declarations": {
+			content: "FROM alpine\nENV FOO=bar \\\n    DEFAULTCMD=\"mock\"\n",
+			assertions: func(dockerfile Dockerfile, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []Variable{{Line: 2, Name: "DEFAULTCMD", Value: "mock"}}, dockerfile.Env("DEFAULTCMD"))
+			},
+		},
+		"Env should return declarations with interpolated ARG values": {
+			content: "ARG COMMAND=global\nFROM alpine\nARG COMMAND\nARG SUFFIX=lint\nENV DEFAULTCMD ${COMMAND}-${SUFFIX}\n",
+			assertions: func(dockerfile Dockerfile, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []Variable{{Line: 5, Name: "DEFAULTCMD", Value: "global-lint"}}, dockerfile.Env("DEFAULTCMD"))
+			},
+		},
+		"Env should not expand meta ARG values that are not declared in the stage": {
+			content: "ARG COMMAND=global\nFROM alpine\nENV DEFAULTCMD=${COMMAND}\n",
+			assertions: func(dockerfile Dockerfile, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []Variable{{Line: 3, Name: "DEFAULTCMD", Value: ""}}, dockerfile.Env("DEFAULTCMD"))
+			},
+		},
+		"Env should return every declaration": {
+			content: "FROM alpine\nENV DEFAULTCMD=foo\nFROM alpine\nENV DEFAULTCMD=bar\n",
+			assertions: func(dockerfile Dockerfile, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []Variable{
+					{Line: 2, Name: "DEFAULTCMD", Value: "foo"},
+					{Line: 4, Name: "DEFAULTCMD", Value: "bar"},
+				}, dockerfile.Env("DEFAULTCMD"))
+			},
+		},
+		"StageEnv should only return the declarations in the stage": {
+			content: "FROM alpine\nENV DEFAULTCMD=foo\nFROM alpine\nENV DEFAULTCMD=bar\n",
+			assertions: func(dockerfile Dockerfile, err error) {
+				assert.Nil(t, err)
+
+				stage, _ := dockerfile.FinalStage()
+				assert.Equal(t, []Variable{{Line: 4, Name: "DEFAULTCMD", Value: "bar"}}, dockerfile.StageEnv(stage, "DEFAULTCMD"))
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dockerfile, err := Parse(test.content)
+
+			test.assertions(dockerfile, err)
+		})
+	}
+}
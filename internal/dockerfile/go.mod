@@ -0,0 +1,18 @@
+module dockerfile
+
+go 1.22
+
+require (
+	github.com/moby/buildkit v0.13.2
+	github.com/stretchr/testify v1.9.0
+)
+
+require (
+	github.com/containerd/typeurl/v2 v2.1.1 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/gogo/protobuf v1.3.2 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	google.golang.org/protobuf v1.31.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
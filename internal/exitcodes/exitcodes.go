@@ -84,6 +84,13 @@ const (
 	// ========================================================================.
 	// Application specific errors (93-113 range)
 	// These should be reserved for program specific errors
+	// ------------------------------------------------------------------------.
+
+	// ChecksFailed One or more checks did not pass.
+	ChecksFailed int = 93
+	// ChecksIncomplete One or more checks could not be completed, or could not
+	// be run at all because of an error.
+	ChecksIncomplete int = 94
 	// ========================================================================.
 
 	// ========================================================================.
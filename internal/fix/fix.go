@@ -0,0 +1,167 @@
+package fix
+
+import (
+	"errors"
+	"fmt"
+	"internal/check"
+	"internal/message"
+	"internal/repofs"
+	"internal/textdiff"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Change is a file that is written to fix a failed check
+type Change struct {
+	Code        string
+	Content     string
+	Description string
+	Path        string
+}
+
+// Apply writes the given changes to the project, creating folders as needed
+func Apply(projectPath string, changes []Change) error {
+	for _, change := range changes {
+		if !filepath.IsLocal(change.Path) {
+			return fmt.Errorf("will not write '%s', it is outside of '%s'", change.Path, projectPath)
+		}
+
+		target := filepath.Join(projectPath, change.Path)
+
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return fmt.Errorf("could not create folder for '%s': %w", change.Path, err)
+		}
+
+		if err := os.WriteFile(target, []byte(change.Content), 0o644); err != nil {
+			return fmt.Errorf("could not write '%s': %w", change.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// CopyFromSkeleton writes the skeleton version of the files for the codes that
+// failed. Files that are not in the skeleton are left alone.
+func CopyFromSkeleton(files repofs.RepoFS, skeleton repofs.RepoFS, fileCodes map[string]string, failed []message.Message) []Change {
+	var changes []Change
+
+	for path, code := range fileCodes {
+		content, err := skeleton.ReadFile(path)
+
+		if err != nil || !hasFailed(code, failed) {
+			continue
+		}
+
+		description := fmt.Sprintf("Created `%s` from the skeleton repository", path)
+
+		if _, exists := files.Stat(path); exists {
+			description = fmt.Sprintf("Restored `%s` from the skeleton repository", path)
+		}
+
+		changes = append(changes, Change{Code: code, Content: content, Description: description, Path: path})
+	}
+
+	return changes
+}
+
+// CreateFolders adds an empty `.gitkeep` file to the folders for the codes
+// that failed, as git does not keep track of empty folders.
+func CreateFolders(fileCodes map[string]string, failed []message.Message) []Change {
+	var changes []Change
+
+	for folder, code := range fileCodes {
+		if hasFailed(code, failed) {
+			changes = append(changes, Change{
+				Code:        code,
+				Description: fmt.Sprintf("Created `%s` with a `.gitkeep` file", folder),
+				Path:        folder + ".gitkeep",
+			})
+		}
+	}
+
+	return changes
+}
+
+func hasFailed(code string, failed []message.Message) bool {
+	result := false
+
+	for _, checkMessage := range failed {
+		if checkMessage.Code == code && checkMessage.Status == check.Fail {
+			result = true
+			break
+		}
+	}
+
+	return result
+}
+
+// Merge sorts the changes by path and removes changes that are not needed: a
+// path that is changed more than once only keeps the first change, and a
+// `.gitkeep` file is left out when another file is written in its folder.
+func Merge(changes []Change) []Change {
+	var merged []Change
+
+	seen := map[string]bool{}
+
+	for _, change := range changes {
+		if seen[change.Path] {
+			continue
+		}
+
+		seen[change.Path] = true
+
+		if filepath.Base(change.Path) == ".gitkeep" {
+			folder := strings.TrimSuffix(change.Path, ".gitkeep")
+			needed := true
+
+			for _, other := range changes {
+				if other.Path != change.Path && strings.HasPrefix(other.Path, folder) {
+					needed = false
+				}
+			}
+
+			if !needed {
+				continue
+			}
+		}
+
+		merged = append(merged, change)
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Path < merged[j].Path
+	})
+
+	return merged
+}
+
+// Patch shows the given changes as a patch, that can be applied with either
+// `git apply` or `patch -p1`.
+func Patch(projectPath string, changes []Change) (string, error) {
+	var patch strings.Builder
+
+	for _, change := range changes {
+		fromName := "a/" + change.Path
+
+		current, err := os.ReadFile(filepath.Join(projectPath, change.Path))
+
+		if errors.Is(err, os.ErrNotExist) {
+			fromName = "/dev/null"
+		} else if err != nil {
+			return "", fmt.Errorf("could not read '%s': %w", change.Path, err)
+		}
+
+		patch.WriteString(fmt.Sprintf("diff --git a/%[1]s b/%[1]s\n", change.Path))
+
+		// A new file that is empty has no lines to show, only the header
+		if fromName == "/dev/null" {
+			patch.WriteString("new file mode 100644\n")
+		}
+
+		patch.WriteString(textdiff.Unified(fromName, string(current), "b/"+change.Path, change.Content))
+	}
+
+	return patch.String(), nil
+}
@@ -0,0 +1,77 @@
+package fix
+
+import (
+	"github.com/stretchr/testify/assert"
+	"internal/check"
+	"internal/message"
+	"internal/repofs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCopyFromSkeleton(t *testing.T) {
+	files := map[string]string{".mdlrc": "changed", ".yamllint": "same"}
+	skeleton := map[string]string{".mdlrc": "original", ".yamllint": "same", "renovate.json": "{}"}
+	fileCodes := map[string]string{".mdlrc": "PLC8001", ".yamllint": "PLC9001", "renovate.json": "PLC14001", "LICENSE": "PLC5007"}
+
+	failed := []message.Message{
+		message.CreateMessage(check.Fail, "PLC8001", "Mock message 8001"),
+		message.CreateMessage(check.Pass, "PLC9001", "Mock message 9001"),
+		message.CreateMessage(check.Fail, "PLC14001", "Mock message 14001"),
+		message.CreateMessage(check.Fail, "PLC5007", "Mock message 5007"),
+	}
+
+	actual := Merge(CopyFromSkeleton(repofs.CreateFromMap(files), repofs.CreateFromMap(skeleton), fileCodes, failed))
+
+	assert.Equal(t, []Change{
+		{Code: "PLC8001", Content: "original", Description: "Restored `.mdlrc` from the skeleton repository", Path: ".mdlrc"},
+		{Code: "PLC14001", Content: "{}", Description: "Created `renovate.json` from the skeleton repository", Path: "renovate.json"},
+	}, actual)
+}
+
+func TestMerge(t *testing.T) {
+	actual := Merge([]Change{
+		{Code: "PLC4002", Path: ".github/.gitkeep"},
+		{Code: "PLC4001", Path: "app/.gitkeep"},
+		{Code: "PLC15001", Path: "app/.gitkeep"},
+		{Code: "PLC16001", Content: "mock", Path: ".github/FUNDING.yml"},
+	})
+
+	assert.Equal(t, []Change{
+		{Code: "PLC16001", Content: "mock", Path: ".github/FUNDING.yml"},
+		{Code: "PLC4001", Path: "app/.gitkeep"},
+	}, actual)
+}
+
+func TestApplyAndPatch(t *testing.T) {
+	projectPath := t.TempDir()
+
+	assert.NoError(t, os.WriteFile(filepath.Join(projectPath, ".mdlrc"), []byte("line 1\nline 2\n"), 0o644))
+
+	changes := []Change{
+		{Code: "PLC8001", Content: "line 1\nline two\n", Path: ".mdlrc"},
+		{Code: "PLC4001", Path: "app/.gitkeep"},
+	}
+
+	patch, err := Patch(projectPath, changes)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "diff --git a/.mdlrc b/.mdlrc\n"+
+		"--- a/.mdlrc\n+++ b/.mdlrc\n@@ -1,2 +1,2 @@\n line 1\n-line 2\n+line two\n"+
+		"diff --git a/app/.gitkeep b/app/.gitkeep\nnew file mode 100644\n", patch)
+
+	assert.NoError(t, Apply(projectPath, changes))
+
+	content, err := os.ReadFile(filepath.Join(projectPath, ".mdlrc"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "line 1\nline two\n", string(content))
+	assert.FileExists(t, filepath.Join(projectPath, "app/.gitkeep"))
+}
+
+func TestApplyOutsideProject(t *testing.T) {
+	err := Apply(t.TempDir(), []Change{{Code: "PLC8001", Path: "../.mdlrc"}})
+
+	assert.ErrorContains(t, err, "will not write '../.mdlrc'")
+}
@@ -0,0 +1,97 @@
+package httpclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultCacheTTL is how long the result for a URL is used, before the URL is
+// checked again.
+const DefaultCacheTTL = 24 * time.Hour
+
+// failedCacheTTL is how long the result for a URL that does not resolve is
+// used at most, whatever the cache TTL is, as that is often temporary.
+const failedCacheTTL = time.Hour
+
+type cacheEntry struct {
+	Checked  time.Time `json:"checked"`
+	Resolves bool      `json:"resolves"`
+	Url      string    `json:"url"`
+}
+
+// DefaultCacheDir returns the directory URL results are cached in, under the
+// user cache dir (which is $XDG_CACHE_HOME on Linux).
+func DefaultCacheDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+
+	return filepath.Join(cacheDir, "plc-lint", "urls"), err
+}
+
+func (c *Client) cachePath(url string) string {
+	hash := sha256.Sum256([]byte(url))
+
+	return filepath.Join(c.options.CacheDir, hex.EncodeToString(hash[:])+".json")
+}
+
+// readCache returns the cached result for the given URL. False is returned
+// as the second value when there is none, or it is too old.
+func (c *Client) readCache(url string) (bool, bool) {
+	var entry cacheEntry
+
+	if c.options.CacheDir == "" {
+		return false, false
+	}
+
+	content, err := os.ReadFile(c.cachePath(url))
+
+	if err != nil || json.Unmarshal(content, &entry) != nil {
+		return false, false
+	}
+
+	ttl := c.options.CacheTTL
+
+	if !entry.Resolves {
+		ttl = min(ttl, failedCacheTTL)
+	}
+
+	if time.Since(entry.Checked) >= ttl {
+		return false, false
+	}
+
+	return entry.Resolves, true
+}
+
+// writeCache stores the result for the given URL. A cache that can not be
+// written is not an error, the URL is checked again next time.
+func (c *Client) writeCache(url string, resolves bool) {
+	if c.options.CacheDir == "" {
+		return
+	}
+
+	content, err := json.Marshal(cacheEntry{Checked: time.Now(), Resolves: resolves, Url: url})
+
+	if err == nil && os.MkdirAll(c.options.CacheDir, 0o755) == nil {
+		// Written under another name first, so a concurrent read never sees half a file
+		temporary, err := os.CreateTemp(c.options.CacheDir, "*.tmp")
+
+		if err == nil {
+			_, err = temporary.Write(content)
+
+			if closeErr := temporary.Close(); err == nil {
+				err = closeErr
+			}
+
+			if err == nil {
+				err = os.Rename(temporary.Name(), c.cachePath(url))
+			}
+
+			if err != nil {
+				_ = os.Remove(temporary.Name())
+			}
+		}
+	}
+}
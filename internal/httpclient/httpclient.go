@@ -0,0 +1,217 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout is how long a single request may take
+const DefaultTimeout = 10 * time.Second
+
+// DefaultUserAgent tells the servers that are checked where requests come from
+const DefaultUserAgent = "plc-lint (+https://gitlab.com/pipeline-components/org/pipeline-components-linter)"
+
+// Options changes how requests are made. Zero values are replaced by the
+// defaults, apart from Retries, CacheDir and Transport.
+type Options struct {
+	// Backoff is the delay before the first retry. It doubles for every retry
+	// after that, unless the server says how long to wait.
+	Backoff time.Duration
+	// CacheDir is where URL results are kept between runs. Empty for none.
+	CacheDir string
+	CacheTTL time.Duration
+	// Proxy is the URL of the proxy requests are sent through. Empty uses the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+	Proxy string
+	// Retries is how often a request is retried after a 429 or 5xx response
+	Retries int
+	Timeout time.Duration
+	// Transport replaces the transport requests are sent with, the Proxy is
+	// not used with it.
+	Transport http.RoundTripper
+	UserAgent string
+}
+
+// Response holds the status and the body of a response, which has already
+// been read.
+type Response struct {
+	Body       []byte
+	StatusCode int
+}
+
+// Client makes the requests of all checks, so they share the same settings,
+// and each URL is only checked once per run.
+type Client struct {
+	client  *http.Client
+	options Options
+	results map[string]bool
+	mutex   sync.Mutex
+}
+
+// CreateClient creates a client with the given options. An error is returned
+// when the proxy is not a valid URL.
+func CreateClient(options Options) (*Client, error) {
+	if options.Backoff == 0 {
+		options.Backoff = time.Second
+	}
+
+	if options.CacheTTL == 0 {
+		options.CacheTTL = DefaultCacheTTL
+	}
+
+	if options.Timeout == 0 {
+		options.Timeout = DefaultTimeout
+	}
+
+	if options.UserAgent == "" {
+		options.UserAgent = DefaultUserAgent
+	}
+
+	transport := options.Transport
+
+	if transport == nil {
+		defaultTransport := http.DefaultTransport.(*http.Transport).Clone()
+
+		if options.Proxy != "" {
+			proxyUrl, err := url.Parse(options.Proxy)
+
+			if err != nil || proxyUrl.Scheme == "" || proxyUrl.Host == "" {
+				return nil, fmt.Errorf("invalid proxy '%s', expected a URL like http://proxy.example.com:3128", options.Proxy)
+			}
+
+			defaultTransport.Proxy = http.ProxyURL(proxyUrl)
+		}
+
+		transport = defaultTransport
+	}
+
+	return &Client{
+		client:  &http.Client{Timeout: options.Timeout, Transport: transport},
+		options: options,
+		results: map[string]bool{},
+	}, nil
+}
+
+// Get requests the given URL, and reads the response
+func (c *Client) Get(ctx context.Context, url string) (Response, error) {
+	var result Response
+
+	response, err := c.do(ctx, http.MethodGet, url)
+
+	if err == nil {
+		result.StatusCode = response.StatusCode
+		result.Body, err = io.ReadAll(response.Body)
+
+		_ = response.Body.Close()
+	}
+
+	return result, err
+}
+
+// Resolves tells whether the given URL answers with a 2xx or 3xx status, after
+// following redirects. Results are kept for the rest of the run, and in the
+// cache dir (when there is one) until the cache TTL has passed, or an hour for
+// a URL that does not resolve. A URL that could not be reached is only
+// remembered for the rest of the run. An error is returned when the context
+// was stopped before the URL was checked, as that says nothing about the URL.
+func (c *Client) Resolves(ctx context.Context, url string) (bool, error) {
+	c.mutex.Lock()
+	resolves, ok := c.results[url]
+	c.mutex.Unlock()
+
+	if ok {
+		return resolves, nil
+	}
+
+	if resolves, ok = c.readCache(url); !ok {
+		var answered bool
+
+		resolves, answered = c.resolve(ctx, url)
+
+		if err := ctx.Err(); err != nil {
+			return false, fmt.Errorf("checking '%s' was stopped: %w", url, err)
+		}
+
+		if answered {
+			c.writeCache(url, resolves)
+		}
+	}
+
+	c.mutex.Lock()
+	c.results[url] = resolves
+	c.mutex.Unlock()
+
+	return resolves, nil
+}
+
+// do sends a request, and retries it (with a growing delay) while the server
+// answers with a 429 or 5xx status.
+func (c *Client) do(ctx context.Context, method string, url string) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		request, err := http.NewRequestWithContext(ctx, method, url, nil)
+
+		if err != nil {
+			return nil, err
+		}
+
+		request.Header.Set("User-Agent", c.options.UserAgent)
+
+		response, err := c.client.Do(request)
+
+		if err != nil || attempt >= c.options.Retries || !isRetryable(response.StatusCode) {
+			return response, err
+		}
+
+		delay := c.options.Backoff << attempt
+
+		if seconds, err := strconv.Atoi(response.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			delay = time.Duration(seconds) * time.Second
+		}
+
+		_, _ = io.Copy(io.Discard, response.Body)
+		_ = response.Body.Close()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+func isRetryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+// resolve checks the URL with a HEAD request first, as that does not download
+// the content. Not every server supports HEAD (or answers it the same way as
+// GET), so a URL that does not resolve with HEAD is tried again with GET. The
+// second value tells whether the server answered at all.
+func (c *Client) resolve(ctx context.Context, url string) (bool, bool) {
+	statusCode, err := c.status(ctx, http.MethodHead, url)
+
+	if err != nil || statusCode >= 400 {
+		statusCode, err = c.status(ctx, http.MethodGet, url)
+	}
+
+	return err == nil && statusCode >= 200 && statusCode <= 399, err == nil
+}
+
+func (c *Client) status(ctx context.Context, method string, url string) (int, error) {
+	response, err := c.do(ctx, method, url)
+
+	if err != nil {
+		return 0, err
+	}
+
+	_, _ = io.Copy(io.Discard, response.Body)
+	_ = response.Body.Close()
+
+	return response.StatusCode, nil
+}
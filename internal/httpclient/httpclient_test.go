@@ -0,0 +1,213 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+type mockServer struct {
+	*httptest.Server
+	mutex    sync.Mutex
+	requests []string
+}
+
+func (m *mockServer) count(request string) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	count := 0
+
+	for _, made := range m.requests {
+		if made == request {
+			count++
+		}
+	}
+
+	return count
+}
+
+func createMockServer(t *testing.T) *mockServer {
+	mock := &mockServer{}
+
+	failures := 0
+
+	mock.Server = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		mock.mutex.Lock()
+		mock.requests = append(mock.requests, request.Method+" "+request.URL.Path)
+		mock.mutex.Unlock()
+
+		switch request.URL.Path {
+		case "/ok":
+			_, _ = writer.Write([]byte(request.Header.Get("User-Agent")))
+		case "/missing":
+			writer.WriteHeader(http.StatusNotFound)
+		case "/no-head":
+			if request.Method == http.MethodHead {
+				writer.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		case "/redirect":
+			http.Redirect(writer, request, "/ok", http.StatusFound)
+		case "/flaky":
+			// Fails twice, then works
+			mock.mutex.Lock()
+			failures++
+			failed := failures <= 2
+			mock.mutex.Unlock()
+
+			if failed {
+				writer.Header().Set("Retry-After", "0")
+				writer.WriteHeader(http.StatusTooManyRequests)
+			}
+		case "/broken":
+			writer.WriteHeader(http.StatusInternalServerError)
+		case "/slow":
+			select {
+			case <-request.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}
+	}))
+
+	t.Cleanup(mock.Close)
+
+	return mock
+}
+
+func TestCreateClient(t *testing.T) {
+	_, err := CreateClient(Options{Proxy: "http://proxy.example.com:3128"})
+
+	assert.NoError(t, err)
+
+	_, err = CreateClient(Options{Proxy: "proxy"})
+
+	assert.EqualError(t, err, "invalid proxy 'proxy', expected a URL like http://proxy.example.com:3128")
+}
+
+func TestGet(t *testing.T) {
+	server := createMockServer(t)
+	client, _ := CreateClient(Options{})
+
+	response, err := client.Get(context.Background(), server.URL+"/ok")
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, DefaultUserAgent, string(response.Body))
+}
+
+func TestGetRetries(t *testing.T) {
+	server := createMockServer(t)
+
+	client, _ := CreateClient(Options{Backoff: time.Millisecond, Retries: 2})
+
+	response, err := client.Get(context.Background(), server.URL+"/flaky")
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, 3, server.count("GET /flaky"))
+
+	response, err = client.Get(context.Background(), server.URL+"/broken")
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
+	assert.Equal(t, 3, server.count("GET /broken"))
+}
+
+// resolves checks the given URL, which must not fail
+func resolves(t *testing.T, client *Client, url string) bool {
+	t.Helper()
+
+	result, err := client.Resolves(context.Background(), url)
+
+	assert.NoError(t, err)
+
+	return result
+}
+
+func TestResolves(t *testing.T) {
+	tests := map[string]struct {
+		expected bool
+		requests []string
+	}{
+		"/ok":       {expected: true, requests: []string{"HEAD /ok"}},
+		"/missing":  {expected: false, requests: []string{"HEAD /missing", "GET /missing"}},
+		"/no-head":  {expected: true, requests: []string{"HEAD /no-head", "GET /no-head"}},
+		"/redirect": {expected: true, requests: []string{"HEAD /redirect", "HEAD /ok"}},
+	}
+
+	for path, test := range tests {
+		t.Run(path, func(t *testing.T) {
+			server := createMockServer(t)
+			client, _ := CreateClient(Options{})
+
+			assert.Equal(t, test.expected, resolves(t, client, server.URL+path))
+
+			// The second time, the result of the first time is used
+			assert.Equal(t, test.expected, resolves(t, client, server.URL+path))
+			assert.Equal(t, test.requests, server.requests)
+		})
+	}
+}
+
+func TestResolvesCancelled(t *testing.T) {
+	server := createMockServer(t)
+	client, _ := CreateClient(Options{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	resolves, err := client.Resolves(ctx, server.URL+"/slow")
+
+	assert.False(t, resolves)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// A request that was stopped is not remembered
+	assert.Empty(t, client.results)
+}
+
+func TestResolvesCache(t *testing.T) {
+	server := createMockServer(t)
+	cacheDir := t.TempDir()
+
+	client, _ := CreateClient(Options{CacheDir: cacheDir})
+
+	assert.True(t, resolves(t, client, server.URL+"/ok"))
+	assert.False(t, resolves(t, client, server.URL+"/missing"))
+
+	// A new run uses the results from the cache on disk
+	client, _ = CreateClient(Options{CacheDir: cacheDir})
+
+	assert.True(t, resolves(t, client, server.URL+"/ok"))
+	assert.False(t, resolves(t, client, server.URL+"/missing"))
+	assert.Equal(t, 1, server.count("HEAD /ok"))
+	assert.Equal(t, 1, server.count("HEAD /missing"))
+
+	// Unless they are too old
+	client, _ = CreateClient(Options{CacheDir: cacheDir, CacheTTL: time.Nanosecond})
+
+	assert.True(t, resolves(t, client, server.URL+"/ok"))
+	assert.Equal(t, 2, server.count("HEAD /ok"))
+}
+
+func TestResolvesCacheFailed(t *testing.T) {
+	server := createMockServer(t)
+	client, _ := CreateClient(Options{CacheDir: t.TempDir()})
+
+	for _, path := range []string{"/ok", "/missing"} {
+		content, _ := json.Marshal(cacheEntry{Checked: time.Now().Add(-2 * time.Hour), Resolves: path == "/ok", Url: server.URL + path})
+
+		assert.NoError(t, os.WriteFile(client.cachePath(server.URL+path), content, 0o644))
+	}
+
+	// A URL that did not resolve is checked again sooner than the cache TTL
+	assert.True(t, resolves(t, client, server.URL+"/ok"))
+	assert.False(t, resolves(t, client, server.URL+"/missing"))
+	assert.Equal(t, 0, server.count("HEAD /ok"))
+	assert.Equal(t, 1, server.count("HEAD /missing"))
+}
@@ -0,0 +1,7 @@
+module httpclienttest
+
+go 1.22
+
+replace internal/httpclient => ../httpclient
+
+require internal/httpclient v0.1.0
@@ -0,0 +1,39 @@
+package httpclienttest
+
+import (
+	"internal/httpclient"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+)
+
+type roundTripper func(request *http.Request) (*http.Response, error)
+
+func (r roundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	return r(request)
+}
+
+// CreateTransport returns a transport that sends every request to the given
+// test server, whatever the host in the URL is. The path and query of the URL
+// are kept.
+func CreateTransport(server *httptest.Server) http.RoundTripper {
+	serverUrl, _ := url.Parse(server.URL)
+
+	return roundTripper(func(request *http.Request) (*http.Response, error) {
+		request = request.Clone(request.Context())
+		request.URL.Scheme = serverUrl.Scheme
+		request.URL.Host = serverUrl.Host
+
+		return server.Client().Transport.RoundTrip(request)
+	})
+}
+
+// CreateClient creates a client that sends every request to the given test
+// server, without retries or a cache on disk, for the tests of checks that
+// make requests to fixed URLs.
+func CreateClient(server *httptest.Server) *httpclient.Client {
+	client, _ := httpclient.CreateClient(httpclient.Options{Backoff: time.Millisecond, Transport: CreateTransport(server)})
+
+	return client
+}
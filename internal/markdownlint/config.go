@@ -0,0 +1,254 @@
+package markdownlint
+
+import (
+	"fmt"
+	"internal/repofs"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	directivePattern = regexp.MustCompile(`^(\w+)\s*(?:\(\s*)?(.*?)(?:\s*\))?$`)
+	parameterPattern = regexp.MustCompile(`:?(\w+)\s*(?:=>|:)\s*("[^"]*"|'[^']*'|[^,\s]+)`)
+	stylePathPrefix  = regexp.MustCompile(`^#\{File\.(?:dirname|expand_path)\(__FILE__\)\}/`)
+
+	// outputSettings only change how `mdl` reports, not what it reports, so they are ignored
+	outputSettings = []string{"json", "show_aliases", "show_kramdown_warnings", "verbose", "warnings"}
+)
+
+// Config holds the rules that are enabled, with their parameters, as defined by
+// an `.mdlrc` file and the style file it refers to.
+type Config struct {
+	Parameters map[string]map[string]string
+	Rules      []string
+}
+
+func unquote(value string) string {
+	value = strings.TrimSpace(value)
+
+	if len(value) > 1 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+
+	return strings.TrimPrefix(value, ":")
+}
+
+// DefaultConfig enables all rules with their default parameters, the same as
+// `mdl` does when no configuration is given.
+func DefaultConfig() Config {
+	config := Config{Parameters: map[string]map[string]string{}}
+
+	for _, rule := range rules {
+		config.Rules = append(config.Rules, rule.id)
+	}
+
+	return config
+}
+
+func (c Config) exclude(ids []string) Config {
+	var remaining []string
+
+	for _, id := range c.Rules {
+		if !slices.Contains(ids, id) {
+			remaining = append(remaining, id)
+		}
+	}
+
+	c.Rules = remaining
+
+	return c
+}
+
+func (c Config) include(ids []string) Config {
+	for _, id := range ids {
+		if !slices.Contains(c.Rules, id) {
+			c.Rules = append(c.Rules, id)
+		}
+	}
+
+	slices.Sort(c.Rules)
+
+	return c
+}
+
+// getRuleIds returns the ids of the rules the given id, alias or tag selects.
+// A selector that does not select any rule is an error, rather than being
+// ignored, so a configured rule is never silently left out.
+func getRuleIds(selector string) ([]string, error) {
+	var ids []string
+
+	for _, rule := range rules {
+		if rule.id == selector || rule.alias == selector || slices.Contains(rule.tags, selector) {
+			ids = append(ids, rule.id)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("unknown rule or tag '%s'", selector)
+	}
+
+	return ids, nil
+}
+
+func parseStyle(content string) (Config, error) {
+	var err error
+
+	config := Config{Parameters: map[string]map[string]string{}}
+
+	for number, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		matches := directivePattern.FindStringSubmatch(line)
+
+		if matches == nil {
+			err = fmt.Errorf("could not parse line %d of style file: %s", number+1, line)
+			break
+		}
+
+		var ids []string
+
+		arguments := strings.SplitN(matches[2], ",", 2)
+		selector := unquote(arguments[0])
+
+		if matches[1] != "all" {
+			if ids, err = getRuleIds(selector); err != nil {
+				err = fmt.Errorf("%w on line %d of style file", err, number+1)
+			}
+		}
+
+		switch {
+		case err != nil:
+			// The directive can not be applied without the rules it selects
+
+		case matches[1] == "all":
+			config = config.include(DefaultConfig().Rules)
+
+		case matches[1] == "rule":
+			config = config.include(ids)
+
+			if len(arguments) > 1 {
+				parameters := map[string]string{}
+
+				for _, parameter := range parameterPattern.FindAllStringSubmatch(arguments[1], -1) {
+					parameters[parameter[1]] = unquote(parameter[2])
+				}
+
+				for _, id := range ids {
+					config.Parameters[id] = parameters
+				}
+			}
+
+		case matches[1] == "tag":
+			config = config.include(ids)
+
+		case matches[1] == "exclude_rule", matches[1] == "exclude_tag":
+			config = config.exclude(ids)
+
+		default:
+			err = fmt.Errorf("unsupported directive '%s' on line %d of style file", matches[1], number+1)
+		}
+
+		if err != nil {
+			break
+		}
+	}
+
+	return config, err
+}
+
+// ParseConfig reads the given `.mdlrc` content. When a `style` is set, the
+// style file is read from the repository files. Any other setting that would
+// change the result of `mdl` is not supported, and is an error, rather than
+// being ignored.
+func ParseConfig(mdlrc string, files repofs.RepoFS) (Config, error) {
+	var (
+		err       error
+		selectors []string
+	)
+
+	config := DefaultConfig()
+
+	for number, line := range strings.Split(mdlrc, "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, _ := strings.Cut(line, " ")
+		value = unquote(value)
+
+		switch key {
+		case "style":
+			stylePath := stylePathPrefix.ReplaceAllString(value, "")
+			stylePath = strings.TrimPrefix(stylePath, "./")
+
+			if styleContent, readErr := files.ReadFile(stylePath); readErr == nil {
+				config, err = parseStyle(styleContent)
+			} else if value != "default" && value != "all" {
+				err = fmt.Errorf("the style file '%s' could not be found", value)
+			}
+
+		case "rules":
+			selectors = strings.Split(value, ",")
+
+		default:
+			if !slices.Contains(outputSettings, key) {
+				err = fmt.Errorf("unsupported setting '%s' on line %d of .mdlrc", key, number+1)
+			}
+		}
+
+		if err != nil {
+			break
+		}
+	}
+
+	if err == nil && len(selectors) > 0 {
+		var (
+			excluded []string
+			included []string
+		)
+
+		// Without any rule to include, the list only excludes rules
+		onlyExcludes := true
+
+		for _, selector := range selectors {
+			var ids []string
+
+			selector = strings.TrimSpace(selector)
+			name := strings.TrimPrefix(selector, "~")
+
+			if selector == "" {
+				continue
+			}
+
+			if ids, err = getRuleIds(name); err != nil {
+				err = fmt.Errorf("%w in rules", err)
+				break
+			}
+
+			if strings.HasPrefix(selector, "~") {
+				excluded = append(excluded, ids...)
+			} else {
+				included = append(included, ids...)
+				onlyExcludes = false
+			}
+		}
+
+		// An explicit list of rules is the whole set of rules that is used
+		if err == nil && !onlyExcludes {
+			config.Rules = slices.DeleteFunc(config.Rules, func(id string) bool {
+				return !slices.Contains(included, id)
+			})
+		}
+
+		config = config.exclude(excluded)
+	}
+
+	return config, err
+}
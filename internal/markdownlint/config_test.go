@@ -0,0 +1,93 @@
+package markdownlint
+
+import (
+	"github.com/stretchr/testify/assert"
+	"internal/repofs"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	tests := map[string]struct {
+		error      string
+		files      map[string]string
+		mdlrc      string
+		parameters map[string]map[string]string
+		rules      []string
+	}{
+		"Empty .mdlrc enables all rules": {
+			mdlrc: "",
+			rules: DefaultConfig().Rules,
+		},
+		"Rules can be selected": {
+			mdlrc: "rules \"MD001,MD013\"",
+			rules: []string{"MD001", "MD013"},
+		},
+		"Rules can be excluded": {
+			mdlrc: "rules \"~MD013,~whitespace\"",
+			rules: DefaultConfig().exclude([]string{"MD009", "MD010", "MD012", "MD013", "MD027", "MD028", "MD030", "MD037", "MD038", "MD039"}).Rules,
+		},
+		"Rules can be selected and excluded": {
+			mdlrc: "rules \"atx_closed,~MD021\"",
+			rules: []string{"MD020"},
+		},
+		"Selected rules are the whole set": {
+			mdlrc: "rules \"MD007\"",
+			rules: []string{"MD007"},
+		},
+		"Unknown rule": {
+			error: "unknown rule or tag 'MD999' in rules",
+			mdlrc: "rules \"MD001,MD999\"",
+		},
+		"Output settings are ignored": {
+			mdlrc: "verbose true\nrules \"MD001\"\n",
+			rules: []string{"MD001"},
+		},
+		"Unsupported setting": {
+			error: "unsupported setting 'ignore_front_matter' on line 2 of .mdlrc",
+			mdlrc: "rules \"MD001\"\nignore_front_matter true\n",
+		},
+		"Unknown setting": {
+			error: "unsupported setting 'rule' on line 1 of .mdlrc",
+			mdlrc: "rule \"MD001\"\n",
+		},
+		"Style file is read": {
+			files: map[string]string{
+				".mdlrc.rb": "all\nrule 'MD013', :line_length => 120\nexclude_rule 'MD041'\n",
+			},
+			mdlrc:      "style \"#{File.dirname(__FILE__)}/.mdlrc.rb\"",
+			parameters: map[string]map[string]string{"MD013": {"line_length": "120"}},
+			rules:      DefaultConfig().exclude([]string{"MD041"}).Rules,
+		},
+		"Missing style file": {
+			error: "the style file '.mdlrc.rb' could not be found",
+			mdlrc: "style '.mdlrc.rb'",
+		},
+		"Unknown rule in style file": {
+			error: "unknown rule or tag 'MD999' on line 2 of style file",
+			files: map[string]string{".mdlrc.rb": "all\nrule 'MD999', :indent => 2\n"},
+			mdlrc: "style '.mdlrc.rb'",
+		},
+		"Unsupported directive in style file": {
+			error: "unsupported directive 'unknown' on line 1 of style file",
+			files: map[string]string{".mdlrc.rb": "unknown 'MD001'"},
+			mdlrc: "style '.mdlrc.rb'",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := ParseConfig(test.mdlrc, repofs.CreateFromMap(test.files))
+
+			if test.error != "" {
+				assert.EqualError(t, err, test.error)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.rules, actual.Rules, "%s expected rules %v, got %v", name, test.rules, actual.Rules)
+
+				if test.parameters != nil {
+					assert.Equal(t, test.parameters, actual.Parameters)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,342 @@
+package markdownlint
+
+import (
+	"github.com/gomarkdown/markdown/ast"
+	"regexp"
+	"strings"
+)
+
+var (
+	atxHeadingPattern     = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	atxClosedPattern      = regexp.MustCompile(`[ \t]#+[ \t]*$`)
+	fencePattern          = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})[ \t]*([^`]*)$")
+	listItemPattern       = regexp.MustCompile(`^(\s*)([*+-]|\d+[.)])(\s+)\S`)
+	setextUnderlinePatten = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+)
+
+type codeBlock struct {
+	fenced bool
+	info   string
+	line   int
+	lines  []string
+}
+
+// listItem is an item of a (possibly nested) list. The expected indent is the
+// indent of the first item at the same level of the same list, the parent is
+// the index of the item it is nested in (-1 for the top level).
+type listItem struct {
+	expectedIndent int
+	indent         int
+	level          int
+	line           int
+	ordered        bool
+	parent         int
+}
+
+type heading struct {
+	level int
+	line  int
+	style string
+	text  string
+}
+
+// document holds the source of a markdown file, split into lines, together
+// with the block structure found in those lines. Line numbers are 1-based.
+type document struct {
+	codeBlocks []codeBlock
+	headings   []heading
+	inCode     []bool
+	lines      []string
+	node       ast.Node
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+func isIndented(line string) bool {
+	return strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")
+}
+
+func isListItem(line string) bool {
+	return listItemPattern.MatchString(line)
+}
+
+func createDocument(source []byte, node ast.Node) *document {
+	doc := &document{
+		lines: strings.Split(strings.ReplaceAll(string(source), "\r\n", "\n"), "\n"),
+		node:  node,
+	}
+
+	// A trailing newline does not start another line
+	if len(doc.lines) > 1 && doc.lines[len(doc.lines)-1] == "" {
+		doc.lines = doc.lines[:len(doc.lines)-1]
+	}
+
+	doc.inCode = make([]bool, len(doc.lines))
+
+	doc.scanBlocks()
+
+	if node != nil {
+		doc.mergeNodes()
+	}
+
+	return doc
+}
+
+func nodeText(node ast.Node) string {
+	text := ""
+
+	ast.WalkFunc(node, func(child ast.Node, entering bool) ast.WalkStatus {
+		if leaf := child.AsLeaf(); entering && leaf != nil {
+			text += string(leaf.Literal)
+		}
+
+		return ast.GoToNext
+	})
+
+	return text
+}
+
+// mergeNodes uses the headings and code blocks from the markdown AST, with the
+// line numbers (and heading style) taken from the matching scanned block.
+func (d *document) mergeNodes() {
+	var (
+		codeBlocks []codeBlock
+		headings   []heading
+	)
+
+	nextCodeBlock := 0
+	nextHeading := 0
+
+	ast.WalkFunc(d.node, func(node ast.Node, entering bool) ast.WalkStatus {
+		if entering {
+			switch node := node.(type) {
+			case *ast.Heading:
+				merged := heading{level: node.Level, text: strings.TrimSpace(nodeText(node))}
+
+				for index := nextHeading; index < len(d.headings); index++ {
+					if d.headings[index].level == node.Level {
+						merged.line = d.headings[index].line
+						merged.style = d.headings[index].style
+						nextHeading = index + 1
+						break
+					}
+				}
+
+				headings = append(headings, merged)
+
+			case *ast.CodeBlock:
+				merged := codeBlock{
+					fenced: node.IsFenced,
+					info:   strings.TrimSpace(string(node.Info)),
+					lines:  strings.Split(strings.TrimSuffix(string(node.Literal), "\n"), "\n"),
+				}
+
+				for index := nextCodeBlock; index < len(d.codeBlocks); index++ {
+					if d.codeBlocks[index].fenced == node.IsFenced {
+						merged.line = d.codeBlocks[index].line
+						nextCodeBlock = index + 1
+						break
+					}
+				}
+
+				codeBlocks = append(codeBlocks, merged)
+			}
+		}
+
+		return ast.GoToNext
+	})
+
+	d.codeBlocks = codeBlocks
+	d.headings = headings
+}
+
+// scanBlocks finds code blocks and headings in the source, so rules can report
+// the line a violation is on.
+func (d *document) scanBlocks() {
+	var (
+		fence   string
+		current *codeBlock
+		inList  bool
+	)
+
+	for index := 0; index < len(d.lines); index++ {
+		line := d.lines[index]
+		lineNumber := index + 1
+
+		if current != nil && current.fenced {
+			d.inCode[index] = true
+
+			if strings.HasPrefix(strings.TrimSpace(line), fence) && strings.Trim(strings.TrimSpace(line), fence[:1]) == "" {
+				d.codeBlocks = append(d.codeBlocks, *current)
+				current = nil
+			} else {
+				current.lines = append(current.lines, line)
+			}
+
+			continue
+		}
+
+		if current != nil {
+			if isIndented(line) || (isBlank(line) && index+1 < len(d.lines) && isIndented(d.lines[index+1])) {
+				d.inCode[index] = true
+				current.lines = append(current.lines, line)
+
+				continue
+			}
+
+			d.codeBlocks = append(d.codeBlocks, *current)
+			current = nil
+		}
+
+		if matches := fencePattern.FindStringSubmatch(line); matches != nil {
+			d.inCode[index] = true
+			fence = matches[1]
+			current = &codeBlock{fenced: true, info: strings.TrimSpace(matches[2]), line: lineNumber}
+
+			continue
+		}
+
+		previousBlank := index == 0 || isBlank(d.lines[index-1])
+
+		if isIndented(line) && previousBlank && !inList {
+			d.inCode[index] = true
+			current = &codeBlock{fenced: false, line: lineNumber, lines: []string{line}}
+
+			continue
+		}
+
+		if isBlank(line) {
+			continue
+		}
+
+		if isListItem(line) {
+			inList = true
+		} else if !isIndented(line) && previousBlank {
+			inList = false
+		}
+
+		if matches := atxHeadingPattern.FindStringSubmatch(line); matches != nil {
+			style := "atx"
+
+			if atxClosedPattern.MatchString(line) && strings.TrimSpace(strings.Trim(strings.TrimSpace(line), "#")) != "" {
+				style = "atx_closed"
+			}
+
+			d.headings = append(d.headings, heading{
+				level: len(matches[1]),
+				line:  lineNumber,
+				style: style,
+				text:  strings.TrimSpace(matches[2]),
+			})
+		} else if index+1 < len(d.lines) && !isListItem(line) && !strings.HasPrefix(strings.TrimSpace(line), ">") {
+			if matches := setextUnderlinePatten.FindStringSubmatch(d.lines[index+1]); matches != nil {
+				level := 1
+
+				if strings.HasPrefix(matches[1], "-") {
+					level = 2
+				}
+
+				d.headings = append(d.headings, heading{
+					level: level,
+					line:  lineNumber,
+					style: "setext",
+					text:  strings.TrimSpace(line),
+				})
+
+				// The underline belongs to the heading
+				index++
+			}
+		}
+	}
+
+	if current != nil {
+		d.codeBlocks = append(d.codeBlocks, *current)
+	}
+}
+
+// listItems returns the items of all lists outside of code blocks. The level
+// of an item follows from its indent: an item indented further than the first
+// item of a level is nested in the item before it.
+func (d *document) listItems() []listItem {
+	var (
+		items   []listItem
+		indents []int
+		last    []int
+	)
+
+	for index, line := range d.lines {
+		if d.inCode[index] {
+			continue
+		}
+
+		matches := listItemPattern.FindStringSubmatch(line)
+
+		if matches == nil || horizontalRulePattern.MatchString(line) {
+			// A paragraph (or other block) after a blank line ends all lists
+			if !isBlank(line) && !isIndented(line) && index > 0 && isBlank(d.lines[index-1]) {
+				indents = nil
+				last = nil
+			}
+
+			continue
+		}
+
+		item := listItem{indent: len(matches[1]), line: index + 1, ordered: !strings.ContainsAny(matches[2], "*+-"), parent: -1}
+
+		for item.level < len(indents) && item.indent > indents[item.level] {
+			item.level++
+		}
+
+		if item.level == len(indents) {
+			indents = append(indents, item.indent)
+			last = append(last, len(items))
+		} else {
+			// The deeper levels of the list have ended
+			indents = indents[:item.level+1]
+			last = last[:item.level+1]
+			last[item.level] = len(items)
+		}
+
+		item.expectedIndent = indents[item.level]
+
+		if item.level > 0 {
+			item.parent = last[item.level-1]
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// findLine returns the number of the first line (outside of code blocks),
+// starting at the given line, that contains the given text. Zero is returned
+// when no such line exists.
+func (d *document) findLine(text string, from int) int {
+	found := 0
+
+	for index := max(from-1, 0); index < len(d.lines); index++ {
+		if !d.inCode[index] && strings.Contains(d.lines[index], text) {
+			found = index + 1
+			break
+		}
+	}
+
+	return found
+}
+
+// isSetextUnderline tells whether the given line underlines a setext heading
+func (d *document) isSetextUnderline(lineNumber int) bool {
+	result := false
+
+	for _, heading := range d.headings {
+		if heading.style == "setext" && heading.line+1 == lineNumber {
+			result = true
+			break
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,73 @@
+package markdownlint
+
+import (
+	"github.com/gomarkdown/markdown/ast"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+type rule struct {
+	alias       string
+	check       func(doc *document, parameters parameters) []int
+	defaults    map[string]string
+	description string
+	id          string
+	tags        []string
+}
+
+type parameters map[string]string
+
+func (p parameters) bool(name string) bool {
+	value, _ := strconv.ParseBool(p[name])
+
+	return value
+}
+
+func (p parameters) int(name string) int {
+	value, _ := strconv.Atoi(p[name])
+
+	return value
+}
+
+type Violation struct {
+	Description string
+	Line        int
+	Rule        string
+}
+
+// Lint checks the given markdown source (and the AST parsed from it) against
+// the rules enabled in the given config.
+func Lint(source []byte, node ast.Node, config Config) []Violation {
+	var violations []Violation
+
+	doc := createDocument(source, node)
+
+	for _, rule := range rules {
+		if slices.Contains(config.Rules, rule.id) {
+			ruleParameters := parameters{}
+
+			for name, value := range rule.defaults {
+				ruleParameters[name] = value
+			}
+
+			for name, value := range config.Parameters[rule.id] {
+				ruleParameters[name] = value
+			}
+
+			for _, line := range rule.check(doc, ruleParameters) {
+				violations = append(violations, Violation{
+					Description: rule.description,
+					Line:        line,
+					Rule:        rule.id,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Line < violations[j].Line
+	})
+
+	return violations
+}
@@ -0,0 +1,183 @@
+package markdownlint
+
+import (
+	"github.com/gomarkdown/markdown/parser"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func lint(source string, config Config) []Violation {
+	markdownParser := parser.NewWithExtensions(parser.CommonExtensions)
+	node := markdownParser.Parse([]byte(source))
+
+	return Lint([]byte(source), node, config)
+}
+
+func TestLint(t *testing.T) {
+	tests := map[string]struct {
+		expected []Violation
+		rules    []string
+		source   string
+	}{
+		"Valid document": {
+			expected: nil,
+			source:   "# Title\n\nSome text.\n\n## Section\n\n- item\n- item\n\n```shell\necho\n```\n",
+		},
+		"MD001 header increment": {
+			expected: []Violation{{Description: "Header levels should only increment by one level at a time", Line: 3, Rule: "MD001"}},
+			rules:    []string{"MD001"},
+			source:   "# Title\n\n### Section\n",
+		},
+		"MD003 mixed header style": {
+			expected: []Violation{{Description: "Header style", Line: 3, Rule: "MD003"}},
+			rules:    []string{"MD003"},
+			source:   "# Title\n\nSection\n-------\n",
+		},
+		"MD004 mixed list markers": {
+			expected: []Violation{{Description: "Unordered list style", Line: 4, Rule: "MD004"}},
+			rules:    []string{"MD004"},
+			source:   "# Title\n\n- one\n* two\n",
+		},
+		"MD005 inconsistent list indent": {
+			expected: []Violation{{Description: "Inconsistent indentation for list items at the same level", Line: 5, Rule: "MD005"}},
+			rules:    []string{"MD005"},
+			source:   "# Title\n\n- one\n   - two\n  - three\n",
+		},
+		"MD006 indented list": {
+			expected: []Violation{{Description: "Consider starting bulleted lists at the beginning of the line", Line: 3, Rule: "MD006"}},
+			rules:    []string{"MD006"},
+			source:   "# Title\n\n  - one\n\n1. one\n   - nested\n",
+		},
+		"MD007 nested list indent": {
+			expected: []Violation{{Description: "Unordered list indentation", Line: 4, Rule: "MD007"}},
+			rules:    []string{"MD007"},
+			source:   "# Title\n\n- one\n  - two\n- three\n   - four\n",
+		},
+		"MD009 trailing spaces": {
+			expected: []Violation{{Description: "Trailing spaces", Line: 3, Rule: "MD009"}},
+			rules:    []string{"MD009"},
+			source:   "# Title\n\nText  \n",
+		},
+		"MD012 multiple blank lines": {
+			expected: []Violation{{Description: "Multiple consecutive blank lines", Line: 3, Rule: "MD012"}},
+			rules:    []string{"MD012"},
+			source:   "# Title\n\n\nText\n",
+		},
+		"MD013 long line": {
+			expected: []Violation{{Description: "Line length", Line: 3, Rule: "MD013"}},
+			rules:    []string{"MD013"},
+			source:   "# Title\n\n" + string(make([]byte, 81)) + "\n",
+		},
+		"MD018 closed atx header": {
+			expected: nil,
+			rules:    []string{"MD018", "MD019"},
+			source:   "#Title#\n\n##  Section  ##\n",
+		},
+		"MD020 closed atx header without space": {
+			expected: []Violation{{Description: "No space inside hashes on closed atx style header", Line: 3, Rule: "MD020"}},
+			rules:    []string{"MD020"},
+			source:   "# Title #\n\n## Section##\n",
+		},
+		"MD021 closed atx header with multiple spaces": {
+			expected: []Violation{{Description: "Multiple spaces inside hashes on closed atx style header", Line: 3, Rule: "MD021"}},
+			rules:    []string{"MD021"},
+			source:   "# Title #\n\n##  Section  ##\n",
+		},
+		"MD022 header without blank lines": {
+			expected: []Violation{{Description: "Headers should be surrounded by blank lines", Line: 2, Rule: "MD022"}},
+			rules:    []string{"MD022"},
+			source:   "Text\n# Title\n\nText\n",
+		},
+		"MD024 duplicate header": {
+			expected: []Violation{{Description: "Multiple headers with the same content", Line: 7, Rule: "MD024"}},
+			rules:    []string{"MD024"},
+			source:   "# A\n\n## Usage\n\n# B\n\n## Usage\n",
+		},
+		"MD025 multiple top level headers": {
+			expected: []Violation{{Description: "Multiple top level headers in the same document", Line: 3, Rule: "MD025"}},
+			rules:    []string{"MD025"},
+			source:   "# Title\n\n# Other\n",
+		},
+		"MD026 trailing punctuation": {
+			expected: []Violation{{Description: "Trailing punctuation in header", Line: 1, Rule: "MD026"}},
+			rules:    []string{"MD026"},
+			source:   "# Title:\n",
+		},
+		"MD033 inline HTML": {
+			expected: []Violation{{Description: "Inline HTML", Line: 3, Rule: "MD033"}},
+			rules:    []string{"MD033"},
+			source:   "# Title\n\nSome <b>bold</b> text\n\n<!-- comment -->\n",
+		},
+		"MD034 bare URL": {
+			expected: []Violation{{Description: "Bare URL used", Line: 3, Rule: "MD034"}},
+			rules:    []string{"MD034"},
+			source:   "# Title\n\nSee https://example.com\n\nSee <https://example.com> or [link](https://example.com)\n",
+		},
+		"MD036 emphasis as header": {
+			expected: []Violation{{Description: "Emphasis used instead of a header", Line: 3, Rule: "MD036"}},
+			rules:    []string{"MD036"},
+			source:   "# Title\n\n**Not a header**\n\n**Note:**\n\n- **item**\n\nSome *emphasis* in text\n",
+		},
+		"MD037 space in emphasis": {
+			expected: []Violation{{Description: "Spaces inside emphasis markers", Line: 3, Rule: "MD037"}},
+			rules:    []string{"MD037"},
+			source:   "# Title\n\nSome ** bold ** text\n\n* item with *emphasis*\n\nA `* b *` span\n",
+		},
+		"MD040 fenced code without language": {
+			expected: []Violation{{Description: "Fenced code blocks should have a language specified", Line: 3, Rule: "MD040"}},
+			rules:    []string{"MD040"},
+			source:   "# Title\n\n```\ncode\n```\n",
+		},
+		"MD041 first line not a header": {
+			expected: []Violation{{Description: "First line in file should be a top level header", Line: 1, Rule: "MD041"}},
+			rules:    []string{"MD041"},
+			source:   "Text\n\n# Title\n",
+		},
+		"MD046 indented code block": {
+			expected: []Violation{{Description: "Code block style", Line: 3, Rule: "MD046"}},
+			rules:    []string{"MD046"},
+			source:   "# Title\n\n    code\n",
+		},
+		"Code blocks are ignored": {
+			expected: nil,
+			rules:    []string{"MD001", "MD012", "MD034"},
+			source:   "# Title\n\n```text\n### Not a header\n\n\nhttps://example.com\n```\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := DefaultConfig()
+
+			if test.rules != nil {
+				config.Rules = test.rules
+			}
+
+			actual := lint(test.source, config)
+
+			assert.Equal(t, test.expected, actual, "%s expected violations %v, got %v", name, test.expected, actual)
+		})
+	}
+}
+
+func TestLintDuplicateHeadersNesting(t *testing.T) {
+	config := Config{
+		Parameters: map[string]map[string]string{"MD024": {"allow_different_nesting": "true"}},
+		Rules:      []string{"MD024"},
+	}
+
+	actual := lint("# A\n\n## Usage\n\n# B\n\n## Usage\n\n## Usage\n", config)
+
+	assert.Equal(t, []Violation{{Description: "Multiple headers with the same content", Line: 9, Rule: "MD024"}}, actual)
+}
+
+func TestLintParameters(t *testing.T) {
+	config := Config{
+		Parameters: map[string]map[string]string{"MD013": {"line_length": "120"}},
+		Rules:      []string{"MD013"},
+	}
+
+	actual := lint("# Title\n\n"+string(make([]byte, 100))+"\n", config)
+
+	assert.Empty(t, actual)
+}
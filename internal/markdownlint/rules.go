@@ -0,0 +1,857 @@
+package markdownlint
+
+import (
+	"github.com/gomarkdown/markdown/ast"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	bareUrlPattern             = regexp.MustCompile(`https?://[^\s>)\]]+`)
+	blockquotePattern          = regexp.MustCompile(`^\s*>`)
+	closedMissingSpacePattern  = regexp.MustCompile(`^#+[^#\s]|[^#\s\\]#+[ \t]*$`)
+	closedMultipleSpacePattern = regexp.MustCompile(`^#+[ \t]{2,}[^#\s]|[^#\s][ \t]{2,}#+[ \t]*$`)
+	codeSpanPattern            = regexp.MustCompile("(`+)(.+?)(`+)")
+	horizontalRulePattern      = regexp.MustCompile(`^ {0,3}([-*_])(?:[ \t]*([-*_])){2,}[ \t]*$`)
+	linkSpacePattern           = regexp.MustCompile(`\[(?:\s+[^\]]*|[^\]]*\s+)\]\(`)
+	linkTargetPattern          = regexp.MustCompile(`<https?://[^>]*>|\]\([^)]*\)|^\s*\[[^\]]+\]:\s*\S+`)
+	missingSpacePattern        = regexp.MustCompile(`^#+[^#\s!]`)
+	multipleSpacePattern       = regexp.MustCompile(`^#+[ \t]{2,}\S`)
+	orderedItemPattern         = regexp.MustCompile(`^(\s*)(\d+)[.)](\s+)\S`)
+	quoteSpacePattern          = regexp.MustCompile(`^\s*>\s{2,}\S`)
+	reversedLinkPattern        = regexp.MustCompile(`\([^)]+\)\[[^\]]+\]`)
+	unorderedItemPattern       = regexp.MustCompile(`^(\s*)([*+-])(\s+)\S`)
+)
+
+// emphasisSpacePatterns find emphasis with a space just inside its markers, as
+// `** bold **`. Go has no back references, so there is a pattern per marker.
+var emphasisSpacePatterns = func() []*regexp.Regexp {
+	var patterns []*regexp.Regexp
+
+	for _, marker := range []string{"**", "*", "__", "_"} {
+		quoted := regexp.QuoteMeta(marker)
+
+		patterns = append(patterns, regexp.MustCompile(`(?:^|\s)`+quoted+`\s.+`+quoted), regexp.MustCompile(quoted+`.+\s`+quoted+`(?:\s|$)`))
+	}
+
+	return patterns
+}()
+
+// isClosedAtx tells whether the given line is a closed atx style header, which
+// has hashes on both sides of its text
+func isClosedAtx(line string) bool {
+	line = strings.TrimRight(line, " \t")
+
+	return strings.HasPrefix(line, "#") && strings.HasSuffix(line, "#") && strings.Trim(line, "# \t") != ""
+}
+
+var rules = []rule{
+	{
+		id: "MD001", alias: "header-increment", tags: []string{"headers"},
+		description: "Header levels should only increment by one level at a time",
+		check: func(doc *document, _ parameters) []int {
+			var lines []int
+
+			for index := 1; index < len(doc.headings); index++ {
+				if doc.headings[index].level > doc.headings[index-1].level+1 {
+					lines = append(lines, doc.headings[index].line)
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD002", alias: "first-header-h1", tags: []string{"headers"},
+		description: "First header should be a top level header",
+		defaults:    map[string]string{"level": "1"},
+		check: func(doc *document, p parameters) []int {
+			var lines []int
+
+			if len(doc.headings) > 0 && doc.headings[0].level != p.int("level") {
+				lines = append(lines, doc.headings[0].line)
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD003", alias: "header-style", tags: []string{"headers"},
+		description: "Header style",
+		defaults:    map[string]string{"style": "consistent"},
+		check: func(doc *document, p parameters) []int {
+			var lines []int
+
+			style := p["style"]
+
+			for index, heading := range doc.headings {
+				expected := style
+
+				if style == "consistent" {
+					expected = doc.headings[0].style
+				} else if style == "setext_with_atx" {
+					expected = "atx"
+
+					if heading.level <= 2 {
+						expected = "setext"
+					}
+				}
+
+				if index > 0 || style != "consistent" {
+					if heading.style != "" && heading.style != expected {
+						lines = append(lines, heading.line)
+					}
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD004", alias: "ul-style", tags: []string{"bullet", "ul"},
+		description: "Unordered list style",
+		defaults:    map[string]string{"style": "consistent"},
+		check: func(doc *document, p parameters) []int {
+			var lines []int
+
+			markers := map[string]string{"asterisk": "*", "dash": "-", "plus": "+"}
+			expected := markers[p["style"]]
+
+			for index, line := range doc.lines {
+				if matches := unorderedItemPattern.FindStringSubmatch(line); matches != nil && !doc.inCode[index] && !horizontalRulePattern.MatchString(line) {
+					if expected == "" {
+						expected = matches[2]
+					} else if matches[2] != expected {
+						lines = append(lines, index+1)
+					}
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD005", alias: "list-indent", tags: []string{"bullet", "ul", "indentation"},
+		description: "Inconsistent indentation for list items at the same level",
+		check: func(doc *document, _ parameters) []int {
+			var lines []int
+
+			for _, item := range doc.listItems() {
+				if item.indent != item.expectedIndent {
+					lines = append(lines, item.line)
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD006", alias: "ul-start-left", tags: []string{"bullet", "ul", "indentation"},
+		description: "Consider starting bulleted lists at the beginning of the line",
+		check: func(doc *document, _ parameters) []int {
+			var lines []int
+
+			for _, item := range doc.listItems() {
+				if item.level == 0 && !item.ordered && item.indent > 0 {
+					lines = append(lines, item.line)
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD007", alias: "ul-indent", tags: []string{"bullet", "ul", "indentation"},
+		description: "Unordered list indentation",
+		defaults:    map[string]string{"indent": "3"},
+		check: func(doc *document, p parameters) []int {
+			var lines []int
+
+			items := doc.listItems()
+
+			for _, item := range items {
+				if item.ordered || item.parent < 0 || items[item.parent].ordered {
+					continue
+				}
+
+				if item.indent-items[item.parent].indent != p.int("indent") {
+					lines = append(lines, item.line)
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD009", alias: "no-trailing-spaces", tags: []string{"whitespace"},
+		description: "Trailing spaces",
+		defaults:    map[string]string{"br_spaces": "0"},
+		check: func(doc *document, p parameters) []int {
+			var lines []int
+
+			for index, line := range doc.lines {
+				trimmed := strings.TrimRight(line, " \t")
+				trailing := line[len(trimmed):]
+
+				if trailing != "" {
+					allowed := p.int("br_spaces") >= 2 && trailing == strings.Repeat(" ", p.int("br_spaces"))
+
+					if !allowed {
+						lines = append(lines, index+1)
+					}
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD010", alias: "no-hard-tabs", tags: []string{"whitespace", "hard_tab"},
+		description: "Hard tabs",
+		defaults:    map[string]string{"ignore_code_blocks": "false"},
+		check: func(doc *document, p parameters) []int {
+			var lines []int
+
+			for index, line := range doc.lines {
+				if strings.Contains(line, "\t") && !(doc.inCode[index] && p.bool("ignore_code_blocks")) {
+					lines = append(lines, index+1)
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD011", alias: "no-reversed-links", tags: []string{"links"},
+		description: "Reversed link syntax",
+		check: func(doc *document, _ parameters) []int {
+			return doc.matchLines(reversedLinkPattern)
+		},
+	},
+	{
+		id: "MD012", alias: "no-multiple-blanks", tags: []string{"whitespace", "blank_lines"},
+		description: "Multiple consecutive blank lines",
+		check: func(doc *document, _ parameters) []int {
+			var lines []int
+
+			for index := 1; index < len(doc.lines); index++ {
+				if isBlank(doc.lines[index]) && isBlank(doc.lines[index-1]) && !doc.inCode[index] {
+					lines = append(lines, index+1)
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD013", alias: "line-length", tags: []string{"line_length"},
+		description: "Line length",
+		defaults:    map[string]string{"code_blocks": "true", "line_length": "80", "tables": "true"},
+		check: func(doc *document, p parameters) []int {
+			var lines []int
+
+			for index, line := range doc.lines {
+				isTable := strings.HasPrefix(strings.TrimSpace(line), "|")
+
+				if doc.inCode[index] && !p.bool("code_blocks") || isTable && !p.bool("tables") {
+					continue
+				}
+
+				if utf8.RuneCountInString(line) > p.int("line_length") {
+					lines = append(lines, index+1)
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD014", alias: "commands-show-output", tags: []string{"code"},
+		description: "Dollar signs used before commands without showing output",
+		check: func(doc *document, _ parameters) []int {
+			var lines []int
+
+			for _, block := range doc.codeBlocks {
+				commands := 0
+				other := 0
+
+				for _, line := range block.lines {
+					if strings.HasPrefix(strings.TrimSpace(line), "$") {
+						commands++
+					} else if !isBlank(line) {
+						other++
+					}
+				}
+
+				if commands > 0 && other == 0 {
+					lines = append(lines, block.line)
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD018", alias: "no-missing-space-atx", tags: []string{"headers", "atx", "spaces"},
+		description: "No space after hash on atx style header",
+		check: func(doc *document, _ parameters) []int {
+			return doc.matchAtxLines(missingSpacePattern, false)
+		},
+	},
+	{
+		id: "MD019", alias: "no-multiple-space-atx", tags: []string{"headers", "atx", "spaces"},
+		description: "Multiple spaces after hash on atx style header",
+		check: func(doc *document, _ parameters) []int {
+			return doc.matchAtxLines(multipleSpacePattern, false)
+		},
+	},
+	{
+		id: "MD020", alias: "no-missing-space-closed-atx", tags: []string{"headers", "atx_closed", "spaces"},
+		description: "No space inside hashes on closed atx style header",
+		check: func(doc *document, _ parameters) []int {
+			return doc.matchAtxLines(closedMissingSpacePattern, true)
+		},
+	},
+	{
+		id: "MD021", alias: "no-multiple-space-closed-atx", tags: []string{"headers", "atx_closed", "spaces"},
+		description: "Multiple spaces inside hashes on closed atx style header",
+		check: func(doc *document, _ parameters) []int {
+			return doc.matchAtxLines(closedMultipleSpacePattern, true)
+		},
+	},
+	{
+		id: "MD022", alias: "blanks-around-headers", tags: []string{"headers", "blank_lines"},
+		description: "Headers should be surrounded by blank lines",
+		check: func(doc *document, _ parameters) []int {
+			var lines []int
+
+			for _, heading := range doc.headings {
+				if heading.line == 0 {
+					continue
+				}
+
+				last := heading.line
+
+				if heading.style == "setext" {
+					last++
+				}
+
+				before := heading.line - 2
+				after := last
+
+				if (before >= 0 && !isBlank(doc.lines[before])) || (after < len(doc.lines) && !isBlank(doc.lines[after])) {
+					lines = append(lines, heading.line)
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD023", alias: "header-start-left", tags: []string{"headers", "spaces"},
+		description: "Headers must start at the beginning of the line",
+		check: func(doc *document, _ parameters) []int {
+			var lines []int
+
+			for _, heading := range doc.headings {
+				if heading.line > 0 && heading.style != "setext" && strings.HasPrefix(doc.lines[heading.line-1], " ") {
+					lines = append(lines, heading.line)
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD024", alias: "no-duplicate-header", tags: []string{"headers"},
+		description: "Multiple headers with the same content",
+		defaults:    map[string]string{"allow_different_nesting": "false"},
+		check: func(doc *document, p parameters) []int {
+			var (
+				lines   []int
+				parents []string
+			)
+
+			seen := map[string]bool{}
+
+			for _, heading := range doc.headings {
+				scope := ""
+
+				// With different nesting allowed, headers only clash under the same parent
+				if p.bool("allow_different_nesting") {
+					// The texts of the headers above this one, one per level
+					parents = parents[:min(heading.level-1, len(parents))]
+
+					for len(parents) < heading.level-1 {
+						parents = append(parents, "")
+					}
+
+					scope = strings.Join(parents, "\x00")
+					parents = append(parents, heading.text)
+				}
+
+				if seen[scope+"\x00"+heading.text] {
+					lines = append(lines, heading.line)
+				}
+
+				seen[scope+"\x00"+heading.text] = true
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD025", alias: "single-h1", tags: []string{"headers"},
+		description: "Multiple top level headers in the same document",
+		defaults:    map[string]string{"level": "1"},
+		check: func(doc *document, p parameters) []int {
+			var lines []int
+
+			found := false
+
+			for _, heading := range doc.headings {
+				if heading.level == p.int("level") {
+					if found {
+						lines = append(lines, heading.line)
+					}
+
+					found = true
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD026", alias: "no-trailing-punctuation", tags: []string{"headers"},
+		description: "Trailing punctuation in header",
+		defaults:    map[string]string{"punctuation": ".,;:!?"},
+		check: func(doc *document, p parameters) []int {
+			var lines []int
+
+			for _, heading := range doc.headings {
+				if heading.text != "" && strings.ContainsAny(heading.text[len(heading.text)-1:], p["punctuation"]) {
+					lines = append(lines, heading.line)
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD027", alias: "no-multiple-space-blockquote", tags: []string{"blockquote", "whitespace", "indentation"},
+		description: "Multiple spaces after blockquote symbol",
+		check: func(doc *document, _ parameters) []int {
+			return doc.matchLines(quoteSpacePattern)
+		},
+	},
+	{
+		id: "MD028", alias: "no-blanks-blockquote", tags: []string{"blockquote", "whitespace"},
+		description: "Blank line inside blockquote",
+		check: func(doc *document, _ parameters) []int {
+			var lines []int
+
+			for index := 1; index+1 < len(doc.lines); index++ {
+				if isBlank(doc.lines[index]) && blockquotePattern.MatchString(doc.lines[index-1]) {
+					next := index + 1
+
+					for next < len(doc.lines) && isBlank(doc.lines[next]) {
+						next++
+					}
+
+					if next < len(doc.lines) && blockquotePattern.MatchString(doc.lines[next]) {
+						lines = append(lines, index+1)
+					}
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD029", alias: "ol-prefix", tags: []string{"ol"},
+		description: "Ordered list item prefix",
+		defaults:    map[string]string{"style": "one"},
+		check: func(doc *document, p parameters) []int {
+			var lines []int
+
+			// The expected number for the next item, per indentation
+			expected := map[string]int{}
+
+			for index, line := range doc.lines {
+				if doc.inCode[index] {
+					continue
+				}
+
+				if matches := orderedItemPattern.FindStringSubmatch(line); matches != nil {
+					number := 0
+					indent := matches[1]
+
+					for _, digit := range matches[2] {
+						number = number*10 + int(digit-'0')
+					}
+
+					if _, ok := expected[indent]; !ok {
+						expected[indent] = number
+					}
+
+					if p["style"] == "one" && number != 1 || p["style"] == "ordered" && number != expected[indent] {
+						lines = append(lines, index+1)
+					}
+
+					expected[indent] = number + 1
+				} else if !isBlank(line) && !isIndented(line) && !isListItem(line) {
+					// A paragraph (or other block) ends all lists
+					expected = map[string]int{}
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD030", alias: "list-marker-space", tags: []string{"ol", "ul", "whitespace"},
+		description: "Spaces after list markers",
+		defaults:    map[string]string{"ol_single": "1", "ul_single": "1"},
+		check: func(doc *document, p parameters) []int {
+			var lines []int
+
+			for index, line := range doc.lines {
+				if doc.inCode[index] || horizontalRulePattern.MatchString(line) {
+					continue
+				}
+
+				if matches := unorderedItemPattern.FindStringSubmatch(line); matches != nil && len(matches[3]) != p.int("ul_single") {
+					lines = append(lines, index+1)
+				} else if matches := orderedItemPattern.FindStringSubmatch(line); matches != nil && len(matches[3]) != p.int("ol_single") {
+					lines = append(lines, index+1)
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD031", alias: "blanks-around-fences", tags: []string{"code", "blank_lines"},
+		description: "Fenced code blocks should be surrounded by blank lines",
+		check: func(doc *document, _ parameters) []int {
+			var lines []int
+
+			for _, block := range doc.codeBlocks {
+				if !block.fenced || block.line == 0 {
+					continue
+				}
+
+				before := block.line - 2
+				after := block.line + len(block.lines) + 1
+
+				if (before >= 0 && !isBlank(doc.lines[before])) || (after < len(doc.lines) && !isBlank(doc.lines[after])) {
+					lines = append(lines, block.line)
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD032", alias: "blanks-around-lists", tags: []string{"bullet", "ul", "ol", "blank_lines"},
+		description: "Lists should be surrounded by blank lines",
+		check: func(doc *document, _ parameters) []int {
+			var lines []int
+
+			for index := 1; index < len(doc.lines); index++ {
+				line := doc.lines[index]
+				previous := doc.lines[index-1]
+
+				if !doc.inCode[index] && isListItem(line) && !horizontalRulePattern.MatchString(line) {
+					if !isBlank(previous) && !isListItem(previous) && !isIndented(previous) && !doc.isSetextUnderline(index+1) {
+						lines = append(lines, index+1)
+					}
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD033", alias: "no-inline-html", tags: []string{"html"},
+		description: "Inline HTML",
+		check: func(doc *document, _ parameters) []int {
+			var lines []int
+
+			if doc.node == nil {
+				return lines
+			}
+
+			from := 1
+
+			ast.WalkFunc(doc.node, func(node ast.Node, entering bool) ast.WalkStatus {
+				var literal string
+
+				switch node := node.(type) {
+				case *ast.HTMLBlock:
+					literal = strings.SplitN(string(node.Literal), "\n", 2)[0]
+				case *ast.HTMLSpan:
+					literal = string(node.Literal)
+				}
+
+				if entering && literal != "" && !strings.HasPrefix(literal, "<!--") && !strings.HasPrefix(literal, "</") {
+					if line := doc.findLine(literal, from); line > 0 {
+						from = line
+						lines = append(lines, line)
+					}
+				}
+
+				return ast.GoToNext
+			})
+
+			return lines
+		},
+	},
+	{
+		id: "MD034", alias: "no-bare-urls", tags: []string{"links", "url"},
+		description: "Bare URL used",
+		check: func(doc *document, _ parameters) []int {
+			var lines []int
+
+			for index, line := range doc.lines {
+				if !doc.inCode[index] {
+					line = codeSpanPattern.ReplaceAllString(line, "")
+					line = linkTargetPattern.ReplaceAllString(line, "")
+
+					if bareUrlPattern.MatchString(line) {
+						lines = append(lines, index+1)
+					}
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD035", alias: "hr-style", tags: []string{"hr"},
+		description: "Horizontal rule style",
+		defaults:    map[string]string{"style": "consistent"},
+		check: func(doc *document, p parameters) []int {
+			var lines []int
+
+			expected := p["style"]
+
+			for index, line := range doc.lines {
+				if doc.inCode[index] || doc.isSetextUnderline(index+1) || !horizontalRulePattern.MatchString(line) {
+					continue
+				}
+
+				if expected == "consistent" {
+					expected = strings.TrimSpace(line)
+				} else if strings.TrimSpace(line) != expected {
+					lines = append(lines, index+1)
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD036", alias: "no-emphasis-as-header", tags: []string{"headers", "emphasis"},
+		description: "Emphasis used instead of a header",
+		defaults:    map[string]string{"punctuation": ".,;:!?"},
+		check: func(doc *document, p parameters) []int {
+			var lines []int
+
+			if doc.node == nil {
+				return lines
+			}
+
+			from := 1
+
+			// Only paragraphs at the top level, not those in lists or quotes
+			for _, child := range doc.node.GetChildren() {
+				paragraph, ok := child.(*ast.Paragraph)
+
+				if !ok {
+					continue
+				}
+
+				var content []ast.Node
+
+				for _, inline := range paragraph.GetChildren() {
+					if text, ok := inline.(*ast.Text); !ok || len(text.Literal) > 0 {
+						content = append(content, inline)
+					}
+				}
+
+				if len(content) != 1 {
+					continue
+				}
+
+				switch content[0].(type) {
+				case *ast.Emph, *ast.Strong:
+					text := nodeText(content[0])
+
+					if text != "" && !strings.Contains(text, "\n") && !strings.ContainsAny(text[len(text)-1:], p["punctuation"]) {
+						if line := doc.findLine(text, from); line > 0 {
+							from = line
+							lines = append(lines, line)
+						}
+					}
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD037", alias: "no-space-in-emphasis", tags: []string{"whitespace", "emphasis"},
+		description: "Spaces inside emphasis markers",
+		check: func(doc *document, _ parameters) []int {
+			var lines []int
+
+			for index, line := range doc.lines {
+				if doc.inCode[index] || horizontalRulePattern.MatchString(line) {
+					continue
+				}
+
+				// Neither a list marker nor a code span is emphasis
+				if matches := unorderedItemPattern.FindStringSubmatchIndex(line); matches != nil {
+					line = line[matches[7]:]
+				}
+
+				line = codeSpanPattern.ReplaceAllString(line, "")
+
+				for _, pattern := range emphasisSpacePatterns {
+					if pattern.MatchString(line) {
+						lines = append(lines, index+1)
+						break
+					}
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD038", alias: "no-space-in-code", tags: []string{"whitespace", "code"},
+		description: "Spaces inside code span elements",
+		check: func(doc *document, _ parameters) []int {
+			var lines []int
+
+			for index, line := range doc.lines {
+				if doc.inCode[index] {
+					continue
+				}
+
+				for _, matches := range codeSpanPattern.FindAllStringSubmatch(line, -1) {
+					content := matches[2]
+
+					if strings.TrimSpace(content) != "" && (strings.HasPrefix(content, " ") != strings.HasSuffix(content, " ") || strings.HasPrefix(content, "  ") || strings.HasSuffix(content, "  ")) {
+						lines = append(lines, index+1)
+						break
+					}
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD039", alias: "no-space-in-links", tags: []string{"whitespace", "links"},
+		description: "Spaces inside link text",
+		check: func(doc *document, _ parameters) []int {
+			return doc.matchLines(linkSpacePattern)
+		},
+	},
+	{
+		id: "MD040", alias: "fenced-code-language", tags: []string{"code", "language"},
+		description: "Fenced code blocks should have a language specified",
+		check: func(doc *document, _ parameters) []int {
+			var lines []int
+
+			for _, block := range doc.codeBlocks {
+				if block.fenced && block.info == "" {
+					lines = append(lines, block.line)
+				}
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD041", alias: "first-line-h1", tags: []string{"headers"},
+		description: "First line in file should be a top level header",
+		defaults:    map[string]string{"level": "1"},
+		check: func(doc *document, p parameters) []int {
+			var lines []int
+
+			firstLine := 0
+
+			for index, line := range doc.lines {
+				if !isBlank(line) {
+					firstLine = index + 1
+					break
+				}
+			}
+
+			if firstLine > 0 && (len(doc.headings) == 0 || doc.headings[0].line != firstLine || doc.headings[0].level != p.int("level")) {
+				lines = append(lines, 1)
+			}
+
+			return lines
+		},
+	},
+	{
+		id: "MD046", alias: "code-block-style", tags: []string{"code"},
+		description: "Code block style",
+		defaults:    map[string]string{"style": "fenced"},
+		check: func(doc *document, p parameters) []int {
+			var lines []int
+
+			style := p["style"]
+
+			for _, block := range doc.codeBlocks {
+				blockStyle := "indented"
+
+				if block.fenced {
+					blockStyle = "fenced"
+				}
+
+				if style == "consistent" {
+					style = blockStyle
+				} else if blockStyle != style {
+					lines = append(lines, block.line)
+				}
+			}
+
+			return lines
+		},
+	},
+}
+
+// matchAtxLines returns the numbers of the lines, outside of code blocks, that
+// match the given pattern and are either closed or open atx style headers.
+func (d *document) matchAtxLines(pattern *regexp.Regexp, closed bool) []int {
+	var lines []int
+
+	for _, line := range d.matchLines(pattern) {
+		if isClosedAtx(d.lines[line-1]) == closed {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+// matchLines returns the numbers of all lines, outside of code blocks, that
+// match the given pattern.
+func (d *document) matchLines(pattern *regexp.Regexp) []int {
+	var lines []int
+
+	for index, line := range d.lines {
+		if !d.inCode[index] && pattern.MatchString(line) {
+			lines = append(lines, index+1)
+		}
+	}
+
+	return lines
+}
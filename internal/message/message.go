@@ -1,20 +1,40 @@
 package message
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"internal/check"
 )
 
 type Marker struct {
+	Error      string
 	Fail       string
 	Incomplete string
 	Pass       string
 	Skip       string
+	Suppressed string
 }
 
 type Message struct {
-	Code    string
+	Code string
+	// Column is the column on the Line the message is about, zero when it is
+	// not known
+	Column int
+	// Diff shows how the content in the component differs from the content in
+	// the skeleton repository, as a unified diff
+	Diff string
+	// Line is the line the message is about, zero when it is not known
+	Line    int
 	Message string
-	Status  check.Status
+	// Path is the file the message is about, when that is not the file (or
+	// folder) the check is about
+	Path string
+	// Reason explains why a check could not be run (for check.Error), could not
+	// finish (for check.Incomplete) or why a failure is deliberate (for
+	// check.Suppressed)
+	Reason string
+	Status check.Status
 }
 
 func CreateMessage(status check.Status, code string, message string) Message {
@@ -24,3 +44,68 @@ func CreateMessage(status check.Status, code string, message string) Message {
 		Status:  status,
 	}
 }
+
+// CreateLocatedMessage creates a message about a line (and column, when it is
+// not zero) in a file. An empty path is the file the check is about.
+func CreateLocatedMessage(status check.Status, code string, path string, line int, column int, message string) Message {
+	return Message{
+		Code:    code,
+		Column:  column,
+		Line:    line,
+		Message: message,
+		Path:    path,
+		Status:  status,
+	}
+}
+
+func CreateErrorMessage(code string, message string, reason string) Message {
+	return Message{
+		Code:    code,
+		Message: message,
+		Reason:  reason,
+		Status:  check.Error,
+	}
+}
+
+func CreateIncompleteMessage(code string, message string, reason string) Message {
+	return Message{
+		Code:    code,
+		Message: message,
+		Reason:  reason,
+		Status:  check.Incomplete,
+	}
+}
+
+// Fingerprint identifies a message, so the same result can be recognised
+// across runs. The line and column are left out, so the fingerprint does not
+// change when lines are added or removed elsewhere in a file.
+func (m Message) Fingerprint() string {
+	hash := sha256.Sum256([]byte(m.Code + "\x00" + m.Path + "\x00" + m.Message))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// Text returns the message with the place it is about in front of it, as
+// `path:line:column: ` for another file, or `Line line: ` for the file the
+// check is about.
+func (m Message) Text() string {
+	location := m.Path
+
+	if location != "" {
+		if m.Line > 0 {
+			location += fmt.Sprintf(":%d", m.Line)
+		}
+
+		if m.Line > 0 && m.Column > 0 {
+			location += fmt.Sprintf(":%d", m.Column)
+		}
+	} else if m.Line > 0 {
+		location = fmt.Sprintf("Line %d", m.Line)
+	}
+
+	if location == "" {
+		return m.Message
+	}
+
+	return location + ": " + m.Message
+}
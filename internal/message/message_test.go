@@ -0,0 +1,83 @@
+package message
+
+import (
+	"github.com/stretchr/testify/assert"
+	"internal/check"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	tests := map[string]struct {
+		a     Message
+		b     Message
+		equal bool
+	}{
+		"Same code and message": {
+			a:     CreateMessage(check.Fail, "PLC6001", "Mock message"),
+			b:     CreateMessage(check.Pass, "PLC6001", "Mock message"),
+			equal: true,
+		},
+		"Different code": {
+			a:     CreateMessage(check.Fail, "PLC6001", "Mock message"),
+			b:     CreateMessage(check.Fail, "PLC6002", "Mock message"),
+			equal: false,
+		},
+		"Different message": {
+			a:     CreateMessage(check.Fail, "PLC6001", "Mock message"),
+			b:     CreateMessage(check.Fail, "PLC6001", "Another mock message"),
+			equal: false,
+		},
+		"Different line": {
+			a:     CreateLocatedMessage(check.Fail, "PLC13001", "", 3, 0, "MD001 Mock message"),
+			b:     CreateLocatedMessage(check.Fail, "PLC13001", "", 7, 0, "MD001 Mock message"),
+			equal: true,
+		},
+		"Different line and column in the same file": {
+			a:     CreateLocatedMessage(check.Fail, "PLC21001", "action.yml", 3, 1, "Mock message (rule)"),
+			b:     CreateLocatedMessage(check.Fail, "PLC21001", "action.yml", 8, 5, "Mock message (rule)"),
+			equal: true,
+		},
+		"Different file": {
+			a:     CreateLocatedMessage(check.Fail, "PLC21001", "action.yml", 3, 1, "Mock message (rule)"),
+			b:     CreateLocatedMessage(check.Fail, "PLC21001", ".gitlab-ci.yml", 3, 1, "Mock message (rule)"),
+			equal: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Len(t, test.a.Fingerprint(), 64)
+			assert.Equal(t, test.equal, test.a.Fingerprint() == test.b.Fingerprint())
+		})
+	}
+}
+
+func TestText(t *testing.T) {
+	tests := map[string]struct {
+		message  Message
+		expected string
+	}{
+		"Without a location": {
+			message:  CreateMessage(check.Fail, "PLC6001", "Mock message"),
+			expected: "Mock message",
+		},
+		"Line in the file of the check": {
+			message:  CreateLocatedMessage(check.Fail, "PLC13001", "", 3, 0, "MD001 Mock message"),
+			expected: "Line 3: MD001 Mock message",
+		},
+		"Line and column in another file": {
+			message:  CreateLocatedMessage(check.Fail, "PLC21001", "action.yml", 3, 1, "Mock message (rule)"),
+			expected: "action.yml:3:1: Mock message (rule)",
+		},
+		"Another file": {
+			message:  CreateLocatedMessage(check.Fail, "PLC21001", "action.yml", 0, 0, "Mock message"),
+			expected: "action.yml: Mock message",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.message.Text())
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"internal/fix"
+	"internal/httpclient"
+	"internal/message"
+	"internal/repofs"
+	repo "internal/repositorycontents"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+var (
+	checks    []Check
+	idPattern = regexp.MustCompile(`^PLC(\d+)$`)
+)
+
+// Context holds everything a check can inspect. It is shared by all checks.
+type Context struct {
+	ComponentName string
+	// Context is done when the check runs out of time, or the run is stopped.
+	// Checks that make network requests should pass it on.
+	Context context.Context
+	Files   repofs.RepoFS
+	// HTTPClient makes the requests of all checks
+	HTTPClient  *httpclient.Client
+	Logs        []repo.LogEntry
+	MainLogs    []repo.LogEntry
+	ProjectPath string
+	RepoDetails repo.Details
+	// RequireSignedCommits is set with `require-signed-commits` in the configuration
+	RequireSignedCommits bool
+	Skeleton             repofs.RepoFS
+}
+
+// Check is a family of checks (for instance `PLC13`), with the codes of the
+// individual checks it reports on. The path is the file or folder in the
+// repository the checks are about, or empty when they are about the
+// repository as a whole.
+type Check interface {
+	Codes() map[string]string
+	ID() string
+	Path() string
+	Run(checkContext Context) []message.Message
+	Title() string
+}
+
+// Fixer is implemented by checks that can fix (some of) their own failures.
+// It is given the messages of the check, and returns the files to write.
+type Fixer interface {
+	Fix(checkContext Context, messages []message.Message) []fix.Change
+}
+
+type definition struct {
+	codes map[string]string
+	id    string
+	path  string
+	run   func(checkContext Context) []message.Message
+	title string
+}
+
+func (c definition) Codes() map[string]string {
+	return c.codes
+}
+
+func (c definition) ID() string {
+	return c.id
+}
+
+func (c definition) Path() string {
+	return c.path
+}
+
+func (c definition) Run(checkContext Context) []message.Message {
+	return c.run(checkContext)
+}
+
+func (c definition) Title() string {
+	return c.title
+}
+
+type fixableDefinition struct {
+	definition
+	fix func(checkContext Context, messages []message.Message) []fix.Change
+}
+
+func (c fixableDefinition) Fix(checkContext Context, messages []message.Message) []fix.Change {
+	return c.fix(checkContext, messages)
+}
+
+func CreateCheck(
+	id string,
+	title string,
+	path string,
+	codes map[string]string,
+	run func(checkContext Context) []message.Message,
+) Check {
+	return definition{
+		codes: codes,
+		id:    id,
+		path:  path,
+		run:   run,
+		title: title,
+	}
+}
+
+// CreateFixableCheck creates a check that also implements Fixer
+func CreateFixableCheck(
+	id string,
+	title string,
+	path string,
+	codes map[string]string,
+	run func(checkContext Context) []message.Message,
+	fix func(checkContext Context, messages []message.Message) []fix.Change,
+) Check {
+	return fixableDefinition{
+		definition: definition{
+			codes: codes,
+			id:    id,
+			path:  path,
+			run:   run,
+			title: title,
+		},
+		fix: fix,
+	}
+}
+
+// Checks returns all registered checks, ordered by their number
+func Checks() []Check {
+	sorted := append([]Check{}, checks...)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return getNumber(sorted[i].ID()) < getNumber(sorted[j].ID())
+	})
+
+	return sorted
+}
+
+// HasCode tells whether one of the registered checks reports the given code
+func HasCode(code string) bool {
+	return slices.ContainsFunc(checks, func(registered Check) bool {
+		_, ok := registered.Codes()[code]
+
+		return ok
+	})
+}
+
+func getNumber(id string) int {
+	number := 0
+
+	if matches := idPattern.FindStringSubmatch(id); matches != nil {
+		number, _ = strconv.Atoi(matches[1])
+	}
+
+	return number
+}
+
+// Register adds a check to the registry. It is meant to be called from the
+// `init()` of a check package, so registering a check with an invalid ID, or
+// an ID or code that has already been registered, panics at startup.
+func Register(newCheck Check) {
+	if !idPattern.MatchString(newCheck.ID()) {
+		panic(fmt.Sprintf("check ID '%s' is invalid, expected 'PLC' followed by a number", newCheck.ID()))
+	}
+
+	for _, registered := range checks {
+		if registered.ID() == newCheck.ID() {
+			panic(fmt.Sprintf("check '%s' has already been registered", newCheck.ID()))
+		}
+
+		for code := range newCheck.Codes() {
+			if _, ok := registered.Codes()[code]; ok {
+				panic(fmt.Sprintf("code '%s' of check '%s' has already been registered by check '%s'", code, newCheck.ID(), registered.ID()))
+			}
+		}
+	}
+
+	checks = append(checks, newCheck)
+}
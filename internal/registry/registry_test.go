@@ -0,0 +1,108 @@
+package registry
+
+import (
+	"github.com/stretchr/testify/assert"
+	"internal/check"
+	"internal/fix"
+	"internal/message"
+	"testing"
+)
+
+func createMockCheck(id string, codes map[string]string) Check {
+	return CreateCheck(id, "Mock title", "mock-file", codes, func(checkContext Context) []message.Message {
+		return []message.Message{message.CreateMessage(check.Pass, id+"001", checkContext.ComponentName)}
+	})
+}
+
+func TestCreateCheck(t *testing.T) {
+	mockCheck := createMockCheck("PLC1", map[string]string{"PLC1001": "Mock description"})
+
+	assert.Equal(t, "PLC1", mockCheck.ID())
+	assert.Equal(t, "Mock title", mockCheck.Title())
+	assert.Equal(t, "mock-file", mockCheck.Path())
+	assert.Equal(t, map[string]string{"PLC1001": "Mock description"}, mockCheck.Codes())
+	assert.Equal(t, []message.Message{message.CreateMessage(check.Pass, "PLC1001", "mock-component")}, mockCheck.Run(Context{ComponentName: "mock-component"}))
+}
+
+func TestCreateFixableCheck(t *testing.T) {
+	mockCheck := CreateFixableCheck("PLC1", "Mock title", "mock-file", map[string]string{"PLC1001": "Mock description"}, func(checkContext Context) []message.Message {
+		return nil
+	}, func(checkContext Context, messages []message.Message) []fix.Change {
+		return []fix.Change{{Code: "PLC1001", Path: checkContext.ComponentName}}
+	})
+
+	fixer, ok := mockCheck.(Fixer)
+
+	assert.True(t, ok)
+	assert.Equal(t, "PLC1", mockCheck.ID())
+	assert.Equal(t, []fix.Change{{Code: "PLC1001", Path: "mock-component"}}, fixer.Fix(Context{ComponentName: "mock-component"}, nil))
+
+	_, ok = createMockCheck("PLC1", map[string]string{"PLC1001": ""}).(Fixer)
+
+	assert.False(t, ok)
+}
+
+func TestRegister(t *testing.T) {
+	tests := map[string]struct {
+		expected []string
+		panic    string
+		register []Check
+	}{
+		"Checks are ordered by number": {
+			expected: []string{"PLC2", "PLC10", "PLC11"},
+			register: []Check{
+				createMockCheck("PLC11", map[string]string{"PLC11001": ""}),
+				createMockCheck("PLC2", map[string]string{"PLC2001": ""}),
+				createMockCheck("PLC10", map[string]string{"PLC10001": ""}),
+			},
+		},
+		"Duplicate ID": {
+			panic: "check 'PLC1' has already been registered",
+			register: []Check{
+				createMockCheck("PLC1", map[string]string{"PLC1001": ""}),
+				createMockCheck("PLC1", map[string]string{"PLC1002": ""}),
+			},
+		},
+		"Duplicate code": {
+			panic: "code 'PLC1001' of check 'PLC2' has already been registered by check 'PLC1'",
+			register: []Check{
+				createMockCheck("PLC1", map[string]string{"PLC1001": ""}),
+				createMockCheck("PLC2", map[string]string{"PLC1001": ""}),
+			},
+		},
+		"Invalid ID": {
+			panic:    "check ID 'Mock' is invalid, expected 'PLC' followed by a number",
+			register: []Check{createMockCheck("Mock", nil)},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			checks = nil
+
+			register := func() {
+				for _, mockCheck := range test.register {
+					Register(mockCheck)
+				}
+			}
+
+			if test.panic != "" {
+				assert.PanicsWithValue(t, test.panic, register)
+			} else {
+				register()
+
+				var actual []string
+
+				for _, registered := range Checks() {
+					actual = append(actual, registered.ID())
+				}
+
+				assert.Equal(t, test.expected, actual)
+				assert.True(t, HasCode(test.expected[0]+"001"))
+				assert.False(t, HasCode("PLC9999"))
+			}
+		})
+	}
+
+	checks = nil
+}
@@ -0,0 +1,94 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"internal/message"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Run runs the given checks concurrently, each with the given timeout (zero
+// for none) on top of the given context. The messages are returned in the
+// order of the checks, and by code within a check, however the checks finish.
+// A check that does not finish in time reports all of its codes as Incomplete.
+//
+// A check that is given up on is not stopped, Go has no way to do that, so it
+// keeps running in the background. Checks must therefore honour the context
+// in Context.Context: pass it on to every request, stop when it is done, and
+// report the codes they could not finish as Incomplete rather than failed.
+func Run(ctx context.Context, checks []Check, checkContext Context, timeout time.Duration) []message.Message {
+	var (
+		messages []message.Message
+		waiting  sync.WaitGroup
+	)
+
+	results := make([][]message.Message, len(checks))
+
+	for index, registered := range checks {
+		waiting.Add(1)
+
+		go func(index int, registered Check) {
+			defer waiting.Done()
+
+			results[index] = runCheck(ctx, registered, checkContext, timeout)
+		}(index, registered)
+	}
+
+	waiting.Wait()
+
+	for _, result := range results {
+		messages = append(messages, result...)
+	}
+
+	return messages
+}
+
+func runCheck(ctx context.Context, registered Check, checkContext Context, timeout time.Duration) []message.Message {
+	var messages []message.Message
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	checkContext.Context = ctx
+
+	// Buffered, so a check that is given up on can still finish in the background
+	done := make(chan []message.Message, 1)
+
+	go func() {
+		done <- registered.Run(checkContext)
+	}()
+
+	select {
+	case messages = <-done:
+		sort.SliceStable(messages, func(i, j int) bool {
+			return messages[i].Code < messages[j].Code
+		})
+	case <-ctx.Done():
+		reason := "The check was stopped before it finished"
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			reason = "The check did not finish in time"
+		}
+
+		codes := registered.Codes()
+		names := make([]string, 0, len(codes))
+
+		for code := range codes {
+			names = append(names, code)
+		}
+
+		sort.Strings(names)
+
+		for _, code := range names {
+			messages = append(messages, message.CreateIncompleteMessage(code, codes[code], reason))
+		}
+	}
+
+	return messages
+}
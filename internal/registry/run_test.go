@@ -0,0 +1,64 @@
+package registry
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"internal/check"
+	"internal/message"
+	"testing"
+	"time"
+)
+
+func createSlowCheck(id string, delay time.Duration) Check {
+	codes := map[string]string{id + "002": "Mock description 2", id + "001": "Mock description 1"}
+
+	return CreateCheck(id, "Mock title", "mock-file", codes, func(checkContext Context) []message.Message {
+		// Like a blocking network request, the context is not looked at
+		time.Sleep(delay)
+
+		return []message.Message{
+			message.CreateMessage(check.Pass, id+"002", codes[id+"002"]),
+			message.CreateMessage(check.Pass, id+"001", codes[id+"001"]),
+		}
+	})
+}
+
+func TestRun(t *testing.T) {
+	checks := []Check{
+		createSlowCheck("PLC1", 20*time.Millisecond),
+		createSlowCheck("PLC2", 0),
+		createSlowCheck("PLC3", time.Hour),
+	}
+
+	start := time.Now()
+	messages := Run(context.Background(), checks, Context{}, 100*time.Millisecond)
+
+	assert.Less(t, time.Since(start), time.Second)
+
+	var codes []string
+
+	for _, checkMessage := range messages {
+		codes = append(codes, checkMessage.Code)
+	}
+
+	// The order of the checks is kept, whichever finishes first
+	assert.Equal(t, []string{"PLC1001", "PLC1002", "PLC2001", "PLC2002", "PLC3001", "PLC3002"}, codes)
+	assert.Equal(t, check.Pass, messages[0].Status)
+	assert.Equal(t, check.Pass, messages[2].Status)
+	assert.Equal(t, message.CreateIncompleteMessage("PLC3001", "Mock description 1", "The check did not finish in time"), messages[4])
+	assert.Equal(t, message.CreateIncompleteMessage("PLC3002", "Mock description 2", "The check did not finish in time"), messages[5])
+}
+
+func TestRunCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	messages := Run(ctx, []Check{createSlowCheck("PLC1", time.Hour)}, Context{}, 0)
+
+	assert.Len(t, messages, 2)
+
+	for _, checkMessage := range messages {
+		assert.Equal(t, check.Incomplete, checkMessage.Status)
+		assert.Equal(t, "The check was stopped before it finished", checkMessage.Reason)
+	}
+}
@@ -0,0 +1,118 @@
+package repofs
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+var errSymlink = errors.New("it is a symbolic link, which is not followed")
+
+// Info describes a file or directory in a RepoFS
+type Info struct {
+	IsDir bool
+	// IsSymlink tells that the path is a symbolic link, which is not followed,
+	// so it is neither a file nor a directory.
+	IsSymlink bool
+	// Size is the size of a file in bytes. It is zero for directories.
+	Size int64
+}
+
+// RepoFS gives access to the files and directories in a repository. The
+// content of a file is only read when it is asked for, so a repository with
+// large files (or a lot of them) is not held in memory as a whole. Paths are
+// relative to the root of the repository and use forward slashes.
+type RepoFS interface {
+	// IsDir tells whether there is a directory at the given path. A trailing
+	// slash is optional.
+	IsDir(name string) bool
+	// List returns the paths of all files and directories, sorted. The path of
+	// a directory ends in a slash.
+	List() []string
+	// ReadFile returns the content of the file at the given path
+	ReadFile(name string) (string, error)
+	// Stat describes the file or directory at the given path. False is returned
+	// when there is nothing at that path.
+	Stat(name string) (Info, bool)
+}
+
+type entry struct {
+	isDir     bool
+	isSymlink bool
+	read      func() ([]byte, error)
+	size      func() int64
+}
+
+// entries is the RepoFS that is shared by all sources. Each source only has to
+// tell which entries there are, and how to read and size them.
+type entries map[string]entry
+
+func (e entries) addDirectories() entries {
+	for name := range e {
+		for directory := path.Dir(strings.TrimSuffix(name, "/")); directory != "."; directory = path.Dir(directory) {
+			e[directory] = entry{isDir: true}
+		}
+	}
+
+	return e
+}
+
+func (e entries) IsDir(name string) bool {
+	info, ok := e.Stat(name)
+
+	return ok && info.IsDir
+}
+
+func (e entries) List() []string {
+	names := make([]string, 0, len(e))
+
+	for name, item := range e {
+		if item.isDir {
+			name += "/"
+		}
+
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func (e entries) ReadFile(name string) (string, error) {
+	item, ok := e[path.Clean(name)]
+
+	if !ok {
+		return "", &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	} else if item.isDir {
+		return "", &fs.PathError{Op: "read", Path: name, Err: errors.New("is a directory")}
+	}
+
+	content, err := item.read()
+
+	if err != nil {
+		return "", fmt.Errorf("could not read '%s': %w", name, err)
+	}
+
+	return string(content), nil
+}
+
+func (e entries) Stat(name string) (Info, bool) {
+	item, ok := e[path.Clean(name)]
+
+	// A trailing slash only matches a directory
+	if !ok || (strings.HasSuffix(name, "/") && !item.isDir) {
+		return Info{}, false
+	}
+
+	info := Info{IsDir: item.isDir, IsSymlink: item.isSymlink}
+
+	if !item.isDir {
+		info.Size = item.size()
+	}
+
+	return info, true
+}
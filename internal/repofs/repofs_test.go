@@ -0,0 +1,64 @@
+package repofs
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/fs"
+	"testing"
+)
+
+var mockFiles = map[string]string{
+	".github/workflows/release.yml": "mock release",
+	"app/":                          "",
+	"README.md":                     "mock readme",
+}
+
+func TestList(t *testing.T) {
+	actual := CreateFromMap(mockFiles).List()
+
+	assert.Equal(t, []string{".github/", ".github/workflows/", ".github/workflows/release.yml", "README.md", "app/"}, actual)
+}
+
+func TestStat(t *testing.T) {
+	tests := map[string]struct {
+		exists   bool
+		expected Info
+		name     string
+	}{
+		"File":                       {exists: true, expected: Info{Size: 11}, name: "README.md"},
+		"File with a trailing slash": {exists: false, expected: Info{}, name: "README.md/"},
+		"Directory":                  {exists: true, expected: Info{IsDir: true}, name: ".github/workflows"},
+		"Directory with a slash":     {exists: true, expected: Info{IsDir: true}, name: ".github/workflows/"},
+		"Empty directory":            {exists: true, expected: Info{IsDir: true}, name: "app/"},
+		"Missing file":               {exists: false, expected: Info{}, name: "LICENSE"},
+		"Root of the repository":     {exists: false, expected: Info{}, name: ""},
+	}
+
+	files := CreateFromMap(mockFiles)
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, exists := files.Stat(test.name)
+
+			assert.Equal(t, test.exists, exists)
+			assert.Equal(t, test.expected, actual)
+			assert.Equal(t, test.exists && test.expected.IsDir, files.IsDir(test.name))
+		})
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	files := CreateFromMap(mockFiles)
+
+	actual, err := files.ReadFile("README.md")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "mock readme", actual)
+
+	_, err = files.ReadFile("LICENSE")
+
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	_, err = files.ReadFile("app/")
+
+	assert.ErrorContains(t, err, "is a directory")
+}
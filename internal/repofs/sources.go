@@ -0,0 +1,171 @@
+package repofs
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"internal/directorylist"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// CreateFromDirectory lists the files and directories in the given directory
+// on disk. Their content is read from disk when it is asked for. Symbolic
+// links are listed, but reading one gives an error rather than following it.
+// The error holds the paths that could not be listed, everything else is
+// still in the returned RepoFS.
+func CreateFromDirectory(root string, options directorylist.Options) (RepoFS, error) {
+	result := entries{}
+
+	listing := directorylist.List(root, options)
+
+	for _, name := range listing.Paths {
+		if strings.HasSuffix(name, "/") {
+			result[strings.TrimSuffix(name, "/")] = entry{isDir: true}
+		} else {
+			fullPath := filepath.Join(root, filepath.FromSlash(name))
+
+			result[name] = entry{
+				read: func() ([]byte, error) {
+					return os.ReadFile(fullPath)
+				},
+				size: func() int64 {
+					var size int64
+
+					if info, err := os.Stat(fullPath); err == nil {
+						size = info.Size()
+					}
+
+					return size
+				},
+			}
+		}
+	}
+
+	for _, name := range listing.Symlinks {
+		result[name] = entry{
+			isSymlink: true,
+			read: func() ([]byte, error) {
+				return nil, errSymlink
+			},
+			size: func() int64 {
+				return 0
+			},
+		}
+	}
+
+	return result, listing.Err()
+}
+
+// CreateFromIndex lists the files that are staged in the index of the given
+// repository, leaving out files with a merge conflict. Their content is read
+// from the object store when it is asked for, one file at a time, as the
+// object store is not safe for concurrent use.
+func CreateFromIndex(repository *git.Repository) (RepoFS, error) {
+	var reading sync.Mutex
+
+	result := entries{}
+
+	staged, err := repository.Storer.Index()
+
+	if err == nil && staged != nil {
+		for _, indexEntry := range staged.Entries {
+			if indexEntry.Stage != 0 {
+				continue
+			}
+
+			hash := indexEntry.Hash
+			size := int64(indexEntry.Size)
+
+			result[indexEntry.Name] = entry{
+				read: func() ([]byte, error) {
+					reading.Lock()
+					defer reading.Unlock()
+
+					blob, err := repository.BlobObject(hash)
+
+					if err != nil {
+						return nil, err
+					}
+
+					return readAll(blob.Reader())
+				},
+				size: func() int64 {
+					return size
+				},
+			}
+		}
+	}
+
+	return result.addDirectories(), err
+}
+
+// CreateFromMap holds the given files in memory, for fixtures and for content
+// that has already been read. Keys that end in a slash are directories, the
+// directories files are in do not have to be listed.
+func CreateFromMap(files map[string]string) RepoFS {
+	result := entries{}
+
+	for name, content := range files {
+		if strings.HasSuffix(name, "/") {
+			result[path.Clean(name)] = entry{isDir: true}
+		} else {
+			content := []byte(content)
+
+			result[path.Clean(name)] = entry{
+				read: func() ([]byte, error) {
+					return content, nil
+				},
+				size: func() int64 {
+					return int64(len(content))
+				},
+			}
+		}
+	}
+
+	return result.addDirectories()
+}
+
+// CreateFromTree lists the files in the given git tree, and the directories
+// they are in. Like CreateFromIndex, their content is read from the object
+// store when it is asked for, one file at a time.
+func CreateFromTree(tree *object.Tree) (RepoFS, error) {
+	var reading sync.Mutex
+
+	result := entries{}
+
+	err := tree.Files().ForEach(func(file *object.File) error {
+		result[file.Name] = entry{
+			read: func() ([]byte, error) {
+				reading.Lock()
+				defer reading.Unlock()
+
+				return readAll(file.Blob.Reader())
+			},
+			size: func() int64 {
+				return file.Size
+			},
+		}
+
+		return nil
+	})
+
+	return result.addDirectories(), err
+}
+
+func readAll(reader io.ReadCloser, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := io.ReadAll(reader)
+
+	if closeErr := reader.Close(); err == nil {
+		err = closeErr
+	}
+
+	return content, err
+}
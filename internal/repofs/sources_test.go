@@ -0,0 +1,109 @@
+package repofs
+
+import (
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"internal/directorylist"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func createRepository(t *testing.T, files map[string]string) *git.Repository {
+	t.Helper()
+
+	repository, _ := git.Init(memory.NewStorage(), memfs.New())
+	worktree, _ := repository.Worktree()
+
+	for fileName, content := range files {
+		file, _ := worktree.Filesystem.Create(fileName)
+		_, _ = file.Write([]byte(content))
+		_ = file.Close()
+		_, _ = worktree.Add(fileName)
+	}
+
+	return repository
+}
+
+func TestCreateFromDirectory(t *testing.T) {
+	root := t.TempDir()
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "app"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "app", "run.sh"), []byte("mock script"), 0o644))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "debug.log"), []byte("mock log"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, ".gitignore"), []byte("*.log\n"), 0o644))
+	assert.NoError(t, os.Symlink(filepath.Join(root, "app", "run.sh"), filepath.Join(root, "run.sh")))
+
+	files, err := CreateFromDirectory(root, directorylist.Options{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{".gitignore", "app/", "app/run.sh", "run.sh"}, files.List())
+
+	_, err = files.ReadFile("run.sh")
+
+	assert.ErrorIs(t, err, errSymlink)
+
+	info, _ := files.Stat("run.sh")
+
+	assert.Equal(t, Info{IsSymlink: true}, info)
+
+	// The content is read when it is asked for, not when the files are listed
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "app", "run.sh"), []byte("changed script"), 0o644))
+
+	content, err := files.ReadFile("app/run.sh")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "changed script", content)
+
+	info, _ = files.Stat("app/run.sh")
+
+	assert.Equal(t, int64(14), info.Size)
+}
+
+func TestCreateFromIndex(t *testing.T) {
+	repository := createRepository(t, map[string]string{"app/run.sh": "mock script"})
+
+	worktree, _ := repository.Worktree()
+	file, _ := worktree.Filesystem.Create("untracked.txt")
+	_ = file.Close()
+
+	files, err := CreateFromIndex(repository)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"app/", "app/run.sh"}, files.List())
+
+	content, err := files.ReadFile("app/run.sh")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "mock script", content)
+}
+
+func TestCreateFromTree(t *testing.T) {
+	repository := createRepository(t, map[string]string{"app/run.sh": "mock script", "README.md": "mock readme"})
+
+	worktree, _ := repository.Worktree()
+	hash, _ := worktree.Commit("Commit message", &git.CommitOptions{
+		Author: &object.Signature{Name: "Mock Author", Email: "mock@example.com", When: time.Now()},
+	})
+	commit, _ := repository.CommitObject(hash)
+	tree, _ := commit.Tree()
+
+	files, err := CreateFromTree(tree)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "app/", "app/run.sh"}, files.List())
+
+	content, err := files.ReadFile("README.md")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "mock readme", content)
+
+	info, _ := files.Stat("app/run.sh")
+
+	assert.Equal(t, int64(11), info.Size)
+}
@@ -0,0 +1,96 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"internal/check"
+	"internal/message"
+	"strings"
+)
+
+type codeQualityLines struct {
+	Begin int `json:"begin"`
+}
+
+type codeQualityLocation struct {
+	Lines codeQualityLines `json:"lines"`
+	Path  string           `json:"path"`
+}
+
+type codeQualityIssue struct {
+	CheckName   string              `json:"check_name"`
+	Description string              `json:"description"`
+	Fingerprint string              `json:"fingerprint"`
+	Location    codeQualityLocation `json:"location"`
+	Severity    string              `json:"severity"`
+}
+
+// getSeverity maps the requirement level of a check to a Code Quality
+// severity, so a broken MUST weighs heavier than a broken MAY.
+func getSeverity(description string) string {
+	severity := "minor"
+
+	switch {
+	case strings.Contains(description, "MUST"):
+		severity = "major"
+	case strings.Contains(description, "SHOULD"):
+		severity = "minor"
+	case strings.Contains(description, "MAY"):
+		severity = "info"
+	}
+
+	return severity
+}
+
+// getFingerprint returns the fingerprint of a message for the given
+// occurrence of it. GitLab merges issues with the same fingerprint, so the
+// same failure on several lines (which has the same message fingerprint) gets
+// the number of the occurrence added, from the second occurrence on.
+func getFingerprint(checkMessage message.Message, occurrence int) string {
+	fingerprint := checkMessage.Fingerprint()
+
+	if occurrence > 0 {
+		hash := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d", fingerprint, occurrence)))
+		fingerprint = hex.EncodeToString(hash[:])
+	}
+
+	return fingerprint
+}
+
+// GitLabCodeQuality renders the failed checks in the given messages as a
+// GitLab Code Quality report. Checks that did not fail are not issues, so
+// they are left out.
+func GitLabCodeQuality(messages []message.Message, families []Family) ([]byte, error) {
+	issues := []codeQualityIssue{}
+	occurrences := map[string]int{}
+
+	for _, checkMessage := range sortMessages(messages) {
+		if checkMessage.Status != check.Fail {
+			continue
+		}
+
+		family := findFamily(checkMessage.Code, families)
+		path, line := getLocation(checkMessage, family)
+
+		occurrence := occurrences[checkMessage.Fingerprint()]
+		occurrences[checkMessage.Fingerprint()]++
+
+		description, ok := family.Codes[checkMessage.Code]
+
+		if !ok {
+			description = checkMessage.Message
+		}
+
+		issues = append(issues, codeQualityIssue{
+			CheckName:   checkMessage.Code,
+			Description: checkMessage.Code + " " + checkMessage.Text(),
+			Fingerprint: getFingerprint(checkMessage, occurrence),
+			Location:    codeQualityLocation{Lines: codeQualityLines{Begin: line}, Path: path},
+			Severity:    getSeverity(description),
+		})
+	}
+
+	return json.MarshalIndent(issues, "", "  ")
+}
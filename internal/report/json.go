@@ -0,0 +1,46 @@
+package report
+
+import (
+	"encoding/json"
+	"internal/message"
+)
+
+type jsonFamily struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+type jsonReport struct {
+	Results  []jsonResult `json:"results"`
+	Skeleton Skeleton     `json:"skeleton"`
+}
+
+type jsonResult struct {
+	Code    string     `json:"code"`
+	Diff    string     `json:"diff,omitempty"`
+	Family  jsonFamily `json:"family"`
+	Message string     `json:"message"`
+	Reason  string     `json:"reason,omitempty"`
+	Status  string     `json:"status"`
+}
+
+// JSON renders the given messages as a JSON object, with one result per message
+// and the skeleton that was used.
+func JSON(messages []message.Message, families []Family, skeleton Skeleton) ([]byte, error) {
+	results := []jsonResult{}
+
+	for _, checkMessage := range sortMessages(messages) {
+		family := findFamily(checkMessage.Code, families)
+
+		results = append(results, jsonResult{
+			Code:    checkMessage.Code,
+			Diff:    checkMessage.Diff,
+			Family:  jsonFamily{ID: family.ID, Title: family.Title},
+			Message: checkMessage.Text(),
+			Reason:  checkMessage.Reason,
+			Status:  getStatusName(checkMessage.Status),
+		})
+	}
+
+	return json.MarshalIndent(jsonReport{Results: results, Skeleton: skeleton}, "", "  ")
+}
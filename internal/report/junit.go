@@ -0,0 +1,154 @@
+package report
+
+import (
+	"encoding/xml"
+	"internal/check"
+	"internal/message"
+	"strings"
+)
+
+type junitResult struct {
+	Message string `xml:"message,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+type junitTestCase struct {
+	ClassName string       `xml:"classname,attr"`
+	Error     *junitResult `xml:"error,omitempty"`
+	Failure   *junitResult `xml:"failure,omitempty"`
+	Name      string       `xml:"name,attr"`
+	Skipped   *junitResult `xml:"skipped,omitempty"`
+}
+
+type junitTestSuite struct {
+	Errors    int             `xml:"errors,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Name      string          `xml:"name,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+	Tests     int             `xml:"tests,attr"`
+}
+
+type junitTestSuites struct {
+	Errors     int              `xml:"errors,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Name       string           `xml:"name,attr"`
+	Skipped    int              `xml:"skipped,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+	Tests      int              `xml:"tests,attr"`
+	XMLName    xml.Name         `xml:"testsuites"`
+}
+
+func createJunitResult(messages []message.Message) *junitResult {
+	var texts []string
+
+	for _, checkMessage := range messages {
+		texts = append(texts, getText(checkMessage))
+	}
+
+	return &junitResult{Message: texts[0], Text: strings.Join(texts, "\n")}
+}
+
+// createTestCase combines all messages for a single code into one test case.
+// An error outweighs a failure, which outweighs a skip. Incomplete checks
+// could not be fully verified and suppressed failures are deliberate, so both
+// are reported as skipped.
+func createTestCase(code string, family Family, messages []message.Message) junitTestCase {
+	byStatus := map[check.Status][]message.Message{}
+
+	for _, checkMessage := range messages {
+		byStatus[checkMessage.Status] = append(byStatus[checkMessage.Status], checkMessage)
+	}
+
+	name := code
+
+	if description, ok := family.Codes[code]; ok {
+		name += " " + description
+	}
+
+	testCase := junitTestCase{ClassName: family.ID, Name: name}
+
+	switch {
+	case len(byStatus[check.Error]) > 0:
+		testCase.Error = createJunitResult(byStatus[check.Error])
+	case len(byStatus[check.Fail]) > 0:
+		testCase.Failure = createJunitResult(byStatus[check.Fail])
+	case len(byStatus[check.Incomplete]) > 0:
+		testCase.Skipped = createJunitResult(byStatus[check.Incomplete])
+	case len(byStatus[check.Suppressed]) > 0:
+		testCase.Skipped = createJunitResult(byStatus[check.Suppressed])
+	case len(byStatus[check.Skip]) > 0 && len(byStatus[check.Pass]) == 0:
+		testCase.Skipped = createJunitResult(byStatus[check.Skip])
+	}
+
+	return testCase
+}
+
+// JUnit renders the given messages as a JUnit XML report, with a test suite
+// per check family and a test case per code.
+func JUnit(messages []message.Message, families []Family) ([]byte, error) {
+	var (
+		codes      []string
+		familyIds  []string
+		suites     = map[string]*junitTestSuite{}
+		byCode     = map[string][]message.Message{}
+		codeFamily = map[string]Family{}
+	)
+
+	for _, checkMessage := range sortMessages(messages) {
+		if _, ok := byCode[checkMessage.Code]; !ok {
+			codes = append(codes, checkMessage.Code)
+			codeFamily[checkMessage.Code] = findFamily(checkMessage.Code, families)
+		}
+
+		byCode[checkMessage.Code] = append(byCode[checkMessage.Code], checkMessage)
+	}
+
+	report := junitTestSuites{Name: toolName}
+
+	for _, code := range codes {
+		family := codeFamily[code]
+
+		suite, ok := suites[family.ID]
+
+		if !ok {
+			name := family.ID
+
+			if family.Title != "" {
+				name += " " + family.Title
+			}
+
+			suite = &junitTestSuite{Name: name}
+			suites[family.ID] = suite
+			familyIds = append(familyIds, family.ID)
+		}
+
+		testCase := createTestCase(code, family, byCode[code])
+
+		suite.Tests++
+
+		if testCase.Error != nil {
+			suite.Errors++
+		} else if testCase.Failure != nil {
+			suite.Failures++
+		} else if testCase.Skipped != nil {
+			suite.Skipped++
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	for _, familyId := range familyIds {
+		suite := suites[familyId]
+
+		report.Errors += suite.Errors
+		report.Failures += suite.Failures
+		report.Skipped += suite.Skipped
+		report.Tests += suite.Tests
+		report.TestSuites = append(report.TestSuites, *suite)
+	}
+
+	output, err := xml.MarshalIndent(report, "", "  ")
+
+	return append([]byte(xml.Header), output...), err
+}
@@ -0,0 +1,134 @@
+package report
+
+import (
+	"fmt"
+	"internal/check"
+	"internal/message"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+var codePattern = regexp.MustCompile(`^(PLC(\d+))(\d{3})$`)
+
+// Family is a group of checks, identified by the prefix of their codes
+// (for instance `PLC13` for the `PLC13001` code). The path, when set, is the
+// file or folder in the repository the checks are about.
+type Family struct {
+	Codes map[string]string
+	ID    string
+	Path  string
+	Title string
+}
+
+// Skeleton is the skeleton repository the component was checked against, so a
+// run can be reproduced. The hash is empty when the skeleton was read from a
+// directory, rather than a commit.
+type Skeleton struct {
+	Hash   string `json:"hash,omitempty"`
+	Ref    string `json:"ref,omitempty"`
+	Source string `json:"source"`
+}
+
+var statusNames = map[check.Status]string{
+	check.Error:      "error",
+	check.Fail:       "fail",
+	check.Incomplete: "incomplete",
+	check.Pass:       "pass",
+	check.Skip:       "skip",
+	check.Suppressed: "suppressed",
+}
+
+// compareCodes sorts codes by their family number first, so `PLC2001` comes
+// before `PLC10001`.
+func compareCodes(a string, b string) bool {
+	matchesA := codePattern.FindStringSubmatch(a)
+	matchesB := codePattern.FindStringSubmatch(b)
+
+	if matchesA == nil || matchesB == nil {
+		return a < b
+	}
+
+	familyA, _ := strconv.Atoi(matchesA[2])
+	familyB, _ := strconv.Atoi(matchesB[2])
+
+	if familyA != familyB {
+		return familyA < familyB
+	}
+
+	return matchesA[3] < matchesB[3]
+}
+
+func findFamily(code string, families []Family) Family {
+	family := Family{}
+
+	if matches := codePattern.FindStringSubmatch(code); matches != nil {
+		family.ID = matches[1]
+	}
+
+	for _, candidate := range families {
+		if candidate.ID == family.ID {
+			family = candidate
+			break
+		}
+	}
+
+	return family
+}
+
+// getText returns the text of a message, together with the reason a check
+// could not be run, if there is one.
+func getText(checkMessage message.Message) string {
+	text := checkMessage.Text()
+
+	if checkMessage.Reason != "" {
+		text = fmt.Sprintf("%s (%s)", text, checkMessage.Reason)
+	}
+
+	return text
+}
+
+// getLocation returns the path and line a message is about. Without a path
+// in the message the path of the family is used, without a line the first.
+func getLocation(checkMessage message.Message, family Family) (string, int) {
+	path := checkMessage.Path
+	line := checkMessage.Line
+
+	if path == "" {
+		path = family.Path
+	}
+
+	if path == "" {
+		path = "."
+	}
+
+	if line == 0 {
+		line = 1
+	}
+
+	return path, line
+}
+
+func getStatusName(status check.Status) string {
+	name, ok := statusNames[status]
+
+	if !ok {
+		name = "unknown"
+	}
+
+	return name
+}
+
+func sortMessages(messages []message.Message) []message.Message {
+	sorted := append([]message.Message{}, messages...)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Code == sorted[j].Code {
+			return sorted[i].Text() < sorted[j].Text()
+		}
+
+		return compareCodes(sorted[i].Code, sorted[j].Code)
+	})
+
+	return sorted
+}
@@ -0,0 +1,286 @@
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"internal/check"
+	"internal/message"
+	"strings"
+	"testing"
+)
+
+var mockFamilies = []Family{
+	{Codes: map[string]string{"PLC2001": "Mock description 2001"}, ID: "PLC2", Title: "Mock family 2"},
+	{Codes: map[string]string{"PLC10001": "Mock description 10001", "PLC10002": "Mock description 10002"}, ID: "PLC10", Title: "Mock family 10"},
+}
+
+var mockMessages = []message.Message{
+	message.CreateMessage(check.Fail, "PLC10002", "Mock message 10002"),
+	message.CreateMessage(check.Pass, "PLC10001", "Mock message 10001"),
+	message.CreateMessage(check.Skip, "PLC2001", "Mock message 2001"),
+	message.CreateErrorMessage("PLC99001", "Mock message 99001", "Mock reason 99001"),
+}
+
+var mockSkeleton = Skeleton{Hash: "0123456789abcdef0123456789abcdef01234567", Ref: "main", Source: "https://example.com/skeleton.git"}
+
+func TestJSON(t *testing.T) {
+	var actual jsonReport
+
+	output, err := JSON(mockMessages, mockFamilies, mockSkeleton)
+
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(output, &actual))
+
+	expected := []jsonResult{
+		{Code: "PLC2001", Family: jsonFamily{ID: "PLC2", Title: "Mock family 2"}, Message: "Mock message 2001", Status: "skip"},
+		{Code: "PLC10001", Family: jsonFamily{ID: "PLC10", Title: "Mock family 10"}, Message: "Mock message 10001", Status: "pass"},
+		{Code: "PLC10002", Family: jsonFamily{ID: "PLC10", Title: "Mock family 10"}, Message: "Mock message 10002", Status: "fail"},
+		{Code: "PLC99001", Family: jsonFamily{ID: "PLC99"}, Message: "Mock message 99001", Reason: "Mock reason 99001", Status: "error"},
+	}
+
+	assert.Equal(t, expected, actual.Results)
+	assert.Equal(t, mockSkeleton, actual.Skeleton)
+}
+
+func TestJSONWithDiff(t *testing.T) {
+	var actual struct {
+		Results []map[string]any `json:"results"`
+	}
+
+	checkMessage := message.CreateMessage(check.Fail, "PLC10002", "Mock message 10002")
+	checkMessage.Diff = "--- component/mock\n+++ skeleton/mock\n@@ -1 +1 @@\n-mock 1\n+mock 2\n"
+
+	output, err := JSON(append([]message.Message{checkMessage}, mockMessages[1:]...), mockFamilies, mockSkeleton)
+
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(output, &actual))
+
+	for _, result := range actual.Results {
+		if result["code"] == "PLC10002" {
+			assert.Equal(t, checkMessage.Diff, result["diff"])
+		} else {
+			assert.NotContains(t, result, "diff")
+		}
+	}
+}
+
+func TestJSONWithoutMessages(t *testing.T) {
+	output, err := JSON(nil, mockFamilies, Skeleton{Source: "skeleton"})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"results": [], "skeleton": {"source": "skeleton"}}`, string(output))
+}
+
+func TestSARIF(t *testing.T) {
+	var actual sarifLog
+
+	output, err := SARIF(mockMessages, mockFamilies, mockSkeleton)
+
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(output, &actual))
+
+	assert.Equal(t, "2.1.0", actual.Version)
+	assert.Len(t, actual.Runs, 1)
+
+	run := actual.Runs[0]
+
+	assert.Equal(t, "plc-lint", run.Tool.Driver.Name)
+	assert.Equal(t, mockSkeleton, run.Properties.Skeleton)
+	assert.Equal(t, []sarifRule{
+		{ID: "PLC2001", Properties: sarifRuleProperties{Tags: []string{"Mock family 2"}}, ShortDescription: sarifText{Text: "Mock description 2001"}},
+		{ID: "PLC10001", Properties: sarifRuleProperties{Tags: []string{"Mock family 10"}}, ShortDescription: sarifText{Text: "Mock description 10001"}},
+		{ID: "PLC10002", Properties: sarifRuleProperties{Tags: []string{"Mock family 10"}}, ShortDescription: sarifText{Text: "Mock description 10002"}},
+	}, run.Tool.Driver.Rules)
+
+	tests := []struct {
+		kind      string
+		level     string
+		ruleId    string
+		ruleIndex *int
+	}{
+		{kind: "notApplicable", level: "none", ruleId: "PLC2001", ruleIndex: intPointer(0)},
+		{kind: "pass", level: "none", ruleId: "PLC10001", ruleIndex: intPointer(1)},
+		{kind: "fail", level: "error", ruleId: "PLC10002", ruleIndex: intPointer(2)},
+		{kind: "open", level: "none", ruleId: "PLC99001", ruleIndex: nil},
+	}
+
+	assert.Len(t, run.Results, len(tests))
+
+	for index, test := range tests {
+		result := run.Results[index]
+
+		assert.Equal(t, test.ruleId, result.RuleID)
+		assert.Equal(t, test.kind, result.Kind, "%s expected kind %v, got %v", test.ruleId, test.kind, result.Kind)
+		assert.Equal(t, test.level, result.Level, "%s expected level %v, got %v", test.ruleId, test.level, result.Level)
+		assert.Equal(t, test.ruleIndex, result.RuleIndex)
+	}
+
+	assert.Equal(t, "Mock message 99001 (Mock reason 99001)", run.Results[3].Message.Text)
+}
+
+func TestSARIFLocations(t *testing.T) {
+	var actual sarifLog
+
+	families := append([]Family{}, mockFamilies...)
+	families[1].Path = "mock-file.yml"
+
+	output, err := SARIF([]message.Message{
+		message.CreateMessage(check.Fail, "PLC2001", "Mock message 2001"),
+		message.CreateMessage(check.Fail, "PLC10001", "Mock message 10001"),
+		message.CreateLocatedMessage(check.Fail, "PLC10002", "", 3, 0, "Mock message 10002"),
+		message.CreateLocatedMessage(check.Fail, "PLC10002", ".gitlab-ci.yml", 7, 2, "Mock message 10002"),
+	}, families, mockSkeleton)
+
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(output, &actual))
+
+	expected := []sarifPhysicalLocation{
+		{ArtifactLocation: sarifArtifactLocation{URI: "."}},
+		{ArtifactLocation: sarifArtifactLocation{URI: "mock-file.yml"}},
+		{ArtifactLocation: sarifArtifactLocation{URI: ".gitlab-ci.yml"}, Region: &sarifRegion{StartColumn: 2, StartLine: 7}},
+		{ArtifactLocation: sarifArtifactLocation{URI: "mock-file.yml"}, Region: &sarifRegion{StartLine: 3}},
+	}
+
+	assert.Len(t, actual.Runs[0].Results, len(expected))
+
+	for index, result := range actual.Runs[0].Results {
+		assert.Equal(t, []sarifLocation{{PhysicalLocation: expected[index]}}, result.Locations)
+	}
+}
+
+func TestSARIFSuppressed(t *testing.T) {
+	var actual sarifLog
+
+	suppressed := message.CreateMessage(check.Suppressed, "PLC10002", "Mock message 10002")
+	suppressed.Reason = "Mock reason 10002"
+
+	output, err := SARIF([]message.Message{suppressed}, mockFamilies, mockSkeleton)
+
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(output, &actual))
+
+	result := actual.Runs[0].Results[0]
+
+	assert.Equal(t, "fail", result.Kind)
+	assert.Equal(t, "error", result.Level)
+	assert.Equal(t, "Mock message 10002", result.Message.Text)
+	assert.Equal(t, []sarifSuppression{{Justification: "Mock reason 10002", Kind: "external"}}, result.Suppressions)
+}
+
+func intPointer(value int) *int {
+	return &value
+}
+
+func TestGitLabCodeQuality(t *testing.T) {
+	var actual []codeQualityIssue
+
+	families := append(mockFamilies, Family{
+		Codes: map[string]string{"PLC21001": "Mock description that MAY fail"},
+		ID:    "PLC21",
+		Title: "Mock family 21",
+	})
+	families[0].Path = "mock-file.yml"
+
+	messages := append(mockMessages,
+		message.CreateLocatedMessage(check.Fail, "PLC2001", "", 3, 0, "Mock message 2001"),
+		message.CreateLocatedMessage(check.Fail, "PLC21001", ".gitlab-ci.yml", 7, 1, "Mock message 21001"),
+	)
+
+	output, err := GitLabCodeQuality(messages, families)
+
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(output, &actual))
+
+	tests := []struct {
+		checkName string
+		line      int
+		path      string
+		severity  string
+	}{
+		{checkName: "PLC2001", line: 3, path: "mock-file.yml", severity: "minor"},
+		{checkName: "PLC10002", line: 1, path: ".", severity: "minor"},
+		{checkName: "PLC21001", line: 7, path: ".gitlab-ci.yml", severity: "info"},
+	}
+
+	assert.Len(t, actual, len(tests))
+
+	for index, test := range tests {
+		issue := actual[index]
+
+		assert.Equal(t, test.checkName, issue.CheckName)
+		assert.Equal(t, test.path, issue.Location.Path, "%s expected path %v, got %v", test.checkName, test.path, issue.Location.Path)
+		assert.Equal(t, test.line, issue.Location.Lines.Begin, "%s expected line %v, got %v", test.checkName, test.line, issue.Location.Lines.Begin)
+		assert.Equal(t, test.severity, issue.Severity, "%s expected severity %v, got %v", test.checkName, test.severity, issue.Severity)
+		assert.Len(t, issue.Fingerprint, 64)
+	}
+
+	assert.NotEqual(t, actual[0].Fingerprint, actual[1].Fingerprint)
+}
+
+func TestGitLabCodeQualityOccurrences(t *testing.T) {
+	var actual []codeQualityIssue
+
+	output, err := GitLabCodeQuality([]message.Message{
+		message.CreateLocatedMessage(check.Fail, "PLC10002", "", 3, 0, "MD013 Mock message"),
+		message.CreateLocatedMessage(check.Fail, "PLC10002", "", 7, 0, "MD013 Mock message"),
+	}, mockFamilies)
+
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(output, &actual))
+
+	assert.Len(t, actual, 2)
+	assert.NotEqual(t, actual[0].Fingerprint, actual[1].Fingerprint)
+}
+
+func TestGetSeverity(t *testing.T) {
+	tests := map[string]string{
+		"The file MUST exist":   "major",
+		"The file SHOULD exist": "minor",
+		"The file MAY exist":    "info",
+		"The file exists":       "minor",
+	}
+
+	for description, expected := range tests {
+		actual := getSeverity(description)
+
+		assert.Equal(t, expected, actual, "%s expected severity %v, got %v", description, expected, actual)
+	}
+}
+
+func TestJUnit(t *testing.T) {
+	var actual junitTestSuites
+
+	messages := append(mockMessages,
+		message.CreateMessage(check.Fail, "PLC10002", "Another mock message 10002"),
+		message.CreateMessage(check.Incomplete, "PLC2002", "Mock message 2002"),
+	)
+
+	output, err := JUnit(messages, mockFamilies)
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(output), "<?xml"))
+	assert.NoError(t, xml.Unmarshal(output, &actual))
+
+	assert.Equal(t, 5, actual.Tests)
+	assert.Equal(t, 1, actual.Errors)
+	assert.Equal(t, 1, actual.Failures)
+	assert.Equal(t, 2, actual.Skipped)
+	assert.Len(t, actual.TestSuites, 3)
+
+	assert.Equal(t, "PLC2 Mock family 2", actual.TestSuites[0].Name)
+	assert.Equal(t, "PLC2001 Mock description 2001", actual.TestSuites[0].TestCases[0].Name)
+	assert.NotNil(t, actual.TestSuites[0].TestCases[0].Skipped)
+	assert.NotNil(t, actual.TestSuites[0].TestCases[1].Skipped)
+
+	failure := actual.TestSuites[1].TestCases[1].Failure
+
+	assert.NotNil(t, failure)
+	assert.Equal(t, "Another mock message 10002\nMock message 10002", failure.Text)
+	assert.Nil(t, actual.TestSuites[1].TestCases[0].Failure)
+	assert.Nil(t, actual.TestSuites[1].TestCases[0].Skipped)
+
+	assert.Equal(t, "PLC99", actual.TestSuites[2].Name)
+	assert.NotNil(t, actual.TestSuites[2].TestCases[0].Error)
+	assert.Equal(t, "Mock message 99001 (Mock reason 99001)", actual.TestSuites[2].TestCases[0].Error.Text)
+}
@@ -0,0 +1,188 @@
+package report
+
+import (
+	"encoding/json"
+	"internal/check"
+	"internal/message"
+	"sort"
+)
+
+const (
+	sarifSchema  = "https://docs.oasis-open.org/sarif/sarif/v2.1.0/errata01/os/schemas/sarif-schema-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolName     = "plc-lint"
+)
+
+type sarifText struct {
+	Text string `json:"text"`
+}
+
+type sarifRuleProperties struct {
+	Tags []string `json:"tags"`
+}
+
+type sarifRule struct {
+	ID               string              `json:"id"`
+	Properties       sarifRuleProperties `json:"properties"`
+	ShortDescription sarifText           `json:"shortDescription"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifSuppression struct {
+	Justification string `json:"justification"`
+	Kind          string `json:"kind"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartColumn int `json:"startColumn,omitempty"`
+	StartLine   int `json:"startLine"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifResult struct {
+	Kind         string             `json:"kind"`
+	Level        string             `json:"level"`
+	Locations    []sarifLocation    `json:"locations"`
+	Message      sarifText          `json:"message"`
+	RuleID       string             `json:"ruleId"`
+	RuleIndex    *int               `json:"ruleIndex,omitempty"`
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifRunProperties struct {
+	Skeleton Skeleton `json:"skeleton"`
+}
+
+type sarifRun struct {
+	Properties sarifRunProperties `json:"properties"`
+	Results    []sarifResult      `json:"results"`
+	Tool       sarifTool          `json:"tool"`
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+// Only failures have a level, for all other kinds of result SARIF requires
+// the level to be "none". A suppressed failure is still a failure, with the
+// suppression (and its justification) added to the result.
+var sarifKinds = map[check.Status]string{
+	check.Error:      "open",
+	check.Fail:       "fail",
+	check.Incomplete: "open",
+	check.Pass:       "pass",
+	check.Skip:       "notApplicable",
+	check.Suppressed: "fail",
+}
+
+// getSarifLocation returns the file a message is about, which code scanning
+// requires for every result. The region is only added when the line is known.
+func getSarifLocation(checkMessage message.Message, family Family) sarifLocation {
+	path, _ := getLocation(checkMessage, family)
+
+	location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: path}}}
+
+	if checkMessage.Line > 0 {
+		location.PhysicalLocation.Region = &sarifRegion{StartColumn: checkMessage.Column, StartLine: checkMessage.Line}
+	}
+
+	return location
+}
+
+func getSarifRules(families []Family) []sarifRule {
+	rules := []sarifRule{}
+
+	for _, family := range families {
+		for code, description := range family.Codes {
+			rules = append(rules, sarifRule{
+				ID:               code,
+				Properties:       sarifRuleProperties{Tags: []string{family.Title}},
+				ShortDescription: sarifText{Text: description},
+			})
+		}
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return compareCodes(rules[i].ID, rules[j].ID)
+	})
+
+	return rules
+}
+
+// SARIF renders the given messages as a SARIF 2.1.0 log, with the codes of the
+// given check families as the rules of the tool. Every result has a location,
+// which is the file of its check when the message is not about another file.
+// The skeleton that was used is added to the properties of the run.
+func SARIF(messages []message.Message, families []Family, skeleton Skeleton) ([]byte, error) {
+	rules := getSarifRules(families)
+	results := []sarifResult{}
+
+	ruleIndexes := map[string]int{}
+
+	for index, rule := range rules {
+		ruleIndexes[rule.ID] = index
+	}
+
+	for _, checkMessage := range sortMessages(messages) {
+		result := sarifResult{
+			Kind:      sarifKinds[checkMessage.Status],
+			Level:     "none",
+			Locations: []sarifLocation{getSarifLocation(checkMessage, findFamily(checkMessage.Code, families))},
+			Message:   sarifText{Text: getText(checkMessage)},
+			RuleID:    checkMessage.Code,
+		}
+
+		if result.Kind == "" {
+			result.Kind = "open"
+		}
+
+		if checkMessage.Status == check.Fail || checkMessage.Status == check.Suppressed {
+			result.Level = "error"
+		}
+
+		if checkMessage.Status == check.Suppressed {
+			result.Message = sarifText{Text: checkMessage.Text()}
+			result.Suppressions = []sarifSuppression{{Justification: checkMessage.Reason, Kind: "external"}}
+		}
+
+		if index, ok := ruleIndexes[checkMessage.Code]; ok {
+			result.RuleIndex = &index
+		}
+
+		results = append(results, result)
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Properties: sarifRunProperties{Skeleton: skeleton},
+			Results:    results,
+			Tool:       sarifTool{Driver: sarifDriver{Name: toolName, Rules: rules}},
+		}},
+	}
+
+	return json.MarshalIndent(log, "", "  ")
+}
@@ -1,20 +1,43 @@
 package repositorycontents
 
-import "time"
+import (
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"time"
+)
 
 type LogEntry struct {
-	//Author    string
-	//Committer string
+	Author      string
+	AuthorEmail string
+	Committer   string
 	//Encoding MessageEncoding
-	//Hash      string
-	//Message   string
-	//ParentHashes []string
-	//PGPSignature string
-	Timestamp time.Time
+	Hash         string
+	Message      string
+	ParentHashes []string
+	PGPSignature string
+	Timestamp    time.Time
 }
 
 type Logs []LogEntry
 
+func CreateLogEntry(commit *object.Commit) LogEntry {
+	var parentHashes []string
+
+	for _, parentHash := range commit.ParentHashes {
+		parentHashes = append(parentHashes, parentHash.String())
+	}
+
+	return LogEntry{
+		Author:       commit.Author.Name,
+		AuthorEmail:  commit.Author.Email,
+		Committer:    commit.Committer.Name,
+		Hash:         commit.Hash.String(),
+		Message:      commit.Message,
+		ParentHashes: parentHashes,
+		PGPSignature: commit.PGPSignature,
+		Timestamp:    commit.Author.When,
+	}
+}
+
 func (l Logs) Len() int {
 	return len(l)
 }
@@ -5,26 +5,23 @@ import (
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/storage/memory"
-	"io"
+	"internal/repofs"
 	"strings"
 )
 
 var gitClone = git.Clone
 var gitPlainOpen = git.PlainOpen
 
-func GetContent(repo string) (map[string]string, error) {
+func GetContent(repo string) (repofs.RepoFS, error) {
 	var (
-		buffer     []byte
 		commit     *object.Commit
 		err        error
-		files      map[string]string
-		reader     io.ReadCloser
+		files      repofs.RepoFS
 		ref        *plumbing.Reference
 		repository *git.Repository
-		tree       *object.Tree
 	)
 
-	files = make(map[string]string)
+	files = repofs.CreateFromMap(nil)
 
 	repository, err = gitClone(memory.NewStorage(), nil, &git.CloneOptions{URL: repo})
 
@@ -35,26 +32,63 @@ func GetContent(repo string) (map[string]string, error) {
 			commit, err = repository.CommitObject(ref.Hash())
 
 			if err == nil && commit != nil {
-				tree, err = commit.Tree()
+				files, err = GetCommitContent(commit)
+			}
+		}
+	}
 
-				if err == nil && tree != nil {
-					fileIter := tree.Files()
+	return files, err
+}
 
-					err = fileIter.ForEach(func(file *object.File) error {
-						reader, err = file.Blob.Reader()
+// GetCommitContent returns the files in the given commit. Their content is
+// read when it is asked for.
+func GetCommitContent(commit *object.Commit) (repofs.RepoFS, error) {
+	tree, err := commit.Tree()
 
-						if err == nil {
-							buffer, err = io.ReadAll(reader)
+	if err != nil {
+		return repofs.CreateFromMap(nil), err
+	}
 
-							if err == nil {
-								contents := string(buffer)
-								files[file.Name] = contents
-							}
-						}
+	return repofs.CreateFromTree(tree)
+}
 
-						return err
-					})
-				}
+// GetIndexContent returns the files in the index of the repository at the
+// given path, as they are staged. Untracked files are left out, and so are
+// files with a merge conflict.
+func GetIndexContent(path string) (repofs.RepoFS, error) {
+	files := repofs.CreateFromMap(nil)
+
+	repository, err := gitPlainOpen(path)
+
+	if err == nil && repository != nil {
+		files, err = repofs.CreateFromIndex(repository)
+	}
+
+	return files, err
+}
+
+// GetRefContent returns the files in the repository at the given path, at the
+// given ref (a branch, tag or commit), without checking anything out.
+func GetRefContent(path string, ref string) (repofs.RepoFS, error) {
+	var (
+		commit *object.Commit
+		err    error
+		files  repofs.RepoFS
+		hash   *plumbing.Hash
+	)
+
+	files = repofs.CreateFromMap(nil)
+
+	repository, err := gitPlainOpen(path)
+
+	if err == nil && repository != nil {
+		hash, err = repository.ResolveRevision(plumbing.Revision(ref))
+
+		if err == nil && hash != nil {
+			commit, err = repository.CommitObject(*hash)
+
+			if err == nil && commit != nil {
+				files, err = GetCommitContent(commit)
 			}
 		}
 	}
@@ -62,6 +96,24 @@ func GetContent(repo string) (map[string]string, error) {
 	return files, err
 }
 
+// ResolveRef returns the hash of the commit the given ref (a branch, tag or
+// commit) points to, in the repository at the given path.
+func ResolveRef(path string, ref string) (string, error) {
+	var hash *plumbing.Hash
+
+	repository, err := gitPlainOpen(path)
+
+	if err == nil && repository != nil {
+		hash, err = repository.ResolveRevision(plumbing.Revision(ref))
+	}
+
+	if err != nil || hash == nil {
+		return "", err
+	}
+
+	return hash.String(), nil
+}
+
 func GetDetails(path string) (Details, error) {
 	var (
 		err     error
@@ -117,6 +169,70 @@ func GetDetails(path string) (Details, error) {
 	return details, err
 }
 
+func GetBranchLogs(path string, branch string) ([]LogEntry, error) {
+	var (
+		err  error
+		log  object.CommitIter
+		logs []LogEntry
+		ref  *plumbing.Reference
+	)
+
+	repository, err := gitPlainOpen(path)
+
+	if err == nil && repository != nil {
+		ref, err = repository.Reference(plumbing.NewBranchReferenceName(branch), true)
+
+		if err != nil {
+			ref, err = repository.Reference(plumbing.NewRemoteReferenceName("origin", branch), true)
+		}
+
+		if err == nil && ref != nil {
+			log, err = repository.Log(&git.LogOptions{From: ref.Hash()})
+
+			if err == nil && log != nil {
+				err = log.ForEach(func(commit *object.Commit) error {
+					logs = append(logs, CreateLogEntry(commit))
+
+					return nil
+				})
+			}
+		}
+	}
+
+	return logs, err
+}
+
+// GetRefLogs returns the history of the repository at the given path, from the
+// given ref (a branch, tag or commit) rather than from HEAD.
+func GetRefLogs(path string, ref string) ([]LogEntry, error) {
+	var (
+		err  error
+		hash *plumbing.Hash
+		log  object.CommitIter
+		logs []LogEntry
+	)
+
+	repository, err := gitPlainOpen(path)
+
+	if err == nil && repository != nil {
+		hash, err = repository.ResolveRevision(plumbing.Revision(ref))
+
+		if err == nil && hash != nil {
+			log, err = repository.Log(&git.LogOptions{From: *hash})
+
+			if err == nil && log != nil {
+				err = log.ForEach(func(commit *object.Commit) error {
+					logs = append(logs, CreateLogEntry(commit))
+
+					return nil
+				})
+			}
+		}
+	}
+
+	return logs, err
+}
+
 func GetLogs(path string) ([]LogEntry, error) {
 	var (
 		err  error
@@ -130,9 +246,7 @@ func GetLogs(path string) ([]LogEntry, error) {
 		log, err = repository.Log(&git.LogOptions{})
 		if err == nil && log != nil {
 			err = log.ForEach(func(commit *object.Commit) error {
-				logs = append(logs, LogEntry{
-					Timestamp: commit.Author.When,
-				})
+				logs = append(logs, CreateLogEntry(commit))
 
 				return nil
 			})
@@ -6,10 +6,14 @@ import (
 	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/storage"
 	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/stretchr/testify/assert"
+	"internal/repofs"
 	"testing"
+	"time"
 )
 
 func createCommit(t *testing.T, repo *git.Repository, files map[string]string) string {
@@ -25,6 +29,7 @@ func createCommit(t *testing.T, repo *git.Repository, files map[string]string) s
 
 	hash, _ := worktree.Commit("Commit message", &git.CommitOptions{
 		AllowEmptyCommits: len(files) == 0,
+		Author:            mockSignature,
 	})
 
 	return hash.String()
@@ -32,6 +37,26 @@ func createCommit(t *testing.T, repo *git.Repository, files map[string]string) s
 
 var mockError = errors.New("mock error")
 
+// readFiles reads all files into a map, for easy comparison. Directories are
+// listed with an empty content.
+func readFiles(t *testing.T, files repofs.RepoFS) map[string]string {
+	t.Helper()
+
+	content := map[string]string{}
+
+	for _, name := range files.List() {
+		content[name], _ = files.ReadFile(name)
+	}
+
+	return content
+}
+
+var mockSignature = &object.Signature{
+	Name:  "Mock Author",
+	Email: "mock@example.com",
+	When:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+}
+
 func TestGetContent(t *testing.T) {
 	mockFiles := map[string]string{"foo.txt": "foo content"}
 
@@ -106,10 +131,10 @@ func TestGetContent(t *testing.T) {
 			gitClone = test.mockFunction
 
 			// Act
-			content, err := GetContent("/mock/path")
+			files, err := GetContent("/mock/path")
 
 			// Assert
-			test.assertions(content, err)
+			test.assertions(readFiles(t, files), err)
 		})
 	}
 }
@@ -164,6 +189,42 @@ func TestGetLogs(t *testing.T) {
 				assert.IsType(t, LogEntry{}, logs[0])
 			},
 		},
+		"GetLogs should populate log entries from commits": {
+			mockFunction: func(path string) (*git.Repository, error) {
+				repository, _ := git.Init(memory.NewStorage(), memfs.New())
+
+				createCommit(t, repository, nil)
+
+				return repository, nil
+			},
+			assertions: func(logs []LogEntry, err error) {
+				assert.Nil(t, err)
+				assert.Len(t, logs, 1)
+				assert.Equal(t, "Mock Author", logs[0].Author)
+				assert.Equal(t, "mock@example.com", logs[0].AuthorEmail)
+				assert.Equal(t, "Mock Author", logs[0].Committer)
+				assert.Equal(t, "Commit message", logs[0].Message)
+				assert.Len(t, logs[0].Hash, 40)
+				assert.Nil(t, logs[0].ParentHashes)
+				assert.Equal(t, "", logs[0].PGPSignature)
+				assert.Equal(t, mockSignature.When, logs[0].Timestamp.UTC())
+			},
+		},
+		"GetLogs should list parent hashes of commits": {
+			mockFunction: func(path string) (*git.Repository, error) {
+				repository, _ := git.Init(memory.NewStorage(), memfs.New())
+
+				createCommit(t, repository, nil)
+				createCommit(t, repository, nil)
+
+				return repository, nil
+			},
+			assertions: func(logs []LogEntry, err error) {
+				assert.Nil(t, err)
+				assert.Len(t, logs, 2)
+				assert.Equal(t, []string{logs[1].Hash}, logs[0].ParentHashes)
+			},
+		},
 	}
 
 	originalFunction := gitPlainOpen
@@ -183,6 +244,88 @@ func TestGetLogs(t *testing.T) {
 	}
 }
 
+func TestGetBranchLogs(t *testing.T) {
+	tests := map[string]struct {
+		mockFunction func(string) (*git.Repository, error)
+		assertions   func([]LogEntry, error)
+	}{
+		"GetBranchLogs should complain when repo could not be opened": {
+			mockFunction: func(path string) (*git.Repository, error) {
+				return nil, mockError
+			},
+			assertions: func(logs []LogEntry, err error) {
+				assert.Equal(t, mockError, err)
+				assert.Nil(t, logs)
+			},
+		},
+		"GetBranchLogs should complain when branch does not exist": {
+			mockFunction: func(path string) (*git.Repository, error) {
+				repository, _ := git.Init(memory.NewStorage(), memfs.New())
+
+				createCommit(t, repository, nil)
+
+				return repository, nil
+			},
+			assertions: func(logs []LogEntry, err error) {
+				assert.Equal(t, plumbing.ErrReferenceNotFound, err)
+				assert.Nil(t, logs)
+			},
+		},
+		"GetBranchLogs should return logs for a local branch": {
+			mockFunction: func(path string) (*git.Repository, error) {
+				repository, _ := git.Init(memory.NewStorage(), memfs.New())
+
+				hash := createCommit(t, repository, nil)
+
+				repository.Storer.SetReference(plumbing.NewHashReference(
+					plumbing.NewBranchReferenceName("main"),
+					plumbing.NewHash(hash),
+				))
+
+				return repository, nil
+			},
+			assertions: func(logs []LogEntry, err error) {
+				assert.Nil(t, err)
+				assert.Len(t, logs, 1)
+			},
+		},
+		"GetBranchLogs should fall back to the branch on the 'origin' remote": {
+			mockFunction: func(path string) (*git.Repository, error) {
+				repository, _ := git.Init(memory.NewStorage(), memfs.New())
+
+				hash := createCommit(t, repository, nil)
+
+				repository.Storer.SetReference(plumbing.NewHashReference(
+					plumbing.NewRemoteReferenceName("origin", "main"),
+					plumbing.NewHash(hash),
+				))
+
+				return repository, nil
+			},
+			assertions: func(logs []LogEntry, err error) {
+				assert.Nil(t, err)
+				assert.Len(t, logs, 1)
+			},
+		},
+	}
+
+	originalFunction := gitPlainOpen
+	defer func() { gitPlainOpen = originalFunction }()
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			gitPlainOpen = test.mockFunction
+
+			// Act
+			logs, err := GetBranchLogs("/mock/path", "main")
+
+			// Assert
+			test.assertions(logs, err)
+		})
+	}
+}
+
 func TestGetDetails(t *testing.T) {
 	tests := map[string]struct {
 		mockFunction func(string) (*git.Repository, error)
@@ -268,3 +411,273 @@ func TestGetDetails(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRefContent(t *testing.T) {
+	tests := map[string]struct {
+		mockFunction func(string) (*git.Repository, error)
+		ref          string
+		assertions   func(map[string]string, error)
+	}{
+		"GetRefContent should complain when repo could not be opened": {
+			mockFunction: func(path string) (*git.Repository, error) {
+				return nil, mockError
+			},
+			ref: "main",
+			assertions: func(content map[string]string, err error) {
+				assert.Equal(t, mockError, err)
+				assert.Len(t, content, 0)
+			},
+		},
+		"GetRefContent should complain when ref does not exist": {
+			mockFunction: func(path string) (*git.Repository, error) {
+				repository, _ := git.Init(memory.NewStorage(), memfs.New())
+
+				createCommit(t, repository, map[string]string{"foo.txt": "foo content"})
+
+				return repository, nil
+			},
+			ref: "unknown",
+			assertions: func(content map[string]string, err error) {
+				assert.Equal(t, plumbing.ErrReferenceNotFound, err)
+				assert.Len(t, content, 0)
+			},
+		},
+		"GetRefContent should return the content at the given ref, with its directories": {
+			mockFunction: func(path string) (*git.Repository, error) {
+				repository, _ := git.Init(memory.NewStorage(), memfs.New())
+
+				hash := createCommit(t, repository, map[string]string{"app/bin/foo.sh": "foo content"})
+				createCommit(t, repository, map[string]string{"bar.txt": "bar content"})
+
+				repository.Storer.SetReference(plumbing.NewHashReference(
+					plumbing.NewBranchReferenceName("feature"),
+					plumbing.NewHash(hash),
+				))
+
+				return repository, nil
+			},
+			ref: "feature",
+			assertions: func(content map[string]string, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, map[string]string{
+					"app/":           "",
+					"app/bin/":       "",
+					"app/bin/foo.sh": "foo content",
+				}, content)
+			},
+		},
+	}
+
+	originalFunction := gitPlainOpen
+	defer func() { gitPlainOpen = originalFunction }()
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			gitPlainOpen = test.mockFunction
+
+			// Act
+			files, err := GetRefContent("/mock/path", test.ref)
+
+			// Assert
+			test.assertions(readFiles(t, files), err)
+		})
+	}
+}
+
+func TestGetRefLogs(t *testing.T) {
+	tests := map[string]struct {
+		mockFunction func(string) (*git.Repository, error)
+		ref          string
+		assertions   func([]LogEntry, error)
+	}{
+		"GetRefLogs should complain when repo could not be opened": {
+			mockFunction: func(path string) (*git.Repository, error) {
+				return nil, mockError
+			},
+			ref: "main",
+			assertions: func(logs []LogEntry, err error) {
+				assert.Equal(t, mockError, err)
+				assert.Nil(t, logs)
+			},
+		},
+		"GetRefLogs should complain when ref does not exist": {
+			mockFunction: func(path string) (*git.Repository, error) {
+				repository, _ := git.Init(memory.NewStorage(), memfs.New())
+
+				createCommit(t, repository, nil)
+
+				return repository, nil
+			},
+			ref: "unknown",
+			assertions: func(logs []LogEntry, err error) {
+				assert.Equal(t, plumbing.ErrReferenceNotFound, err)
+				assert.Nil(t, logs)
+			},
+		},
+		"GetRefLogs should return the logs from the given ref, rather than HEAD": {
+			mockFunction: func(path string) (*git.Repository, error) {
+				repository, _ := git.Init(memory.NewStorage(), memfs.New())
+
+				hash := createCommit(t, repository, nil)
+				createCommit(t, repository, nil)
+
+				repository.Storer.SetReference(plumbing.NewHashReference(
+					plumbing.NewBranchReferenceName("feature"),
+					plumbing.NewHash(hash),
+				))
+
+				return repository, nil
+			},
+			ref: "feature",
+			assertions: func(logs []LogEntry, err error) {
+				assert.Nil(t, err)
+				assert.Len(t, logs, 1)
+				assert.Nil(t, logs[0].ParentHashes)
+			},
+		},
+	}
+
+	originalFunction := gitPlainOpen
+	defer func() { gitPlainOpen = originalFunction }()
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			gitPlainOpen = test.mockFunction
+
+			// Act
+			logs, err := GetRefLogs("/mock/path", test.ref)
+
+			// Assert
+			test.assertions(logs, err)
+		})
+	}
+}
+
+func TestResolveRef(t *testing.T) {
+	var hash string
+
+	tests := map[string]struct {
+		mockFunction func(string) (*git.Repository, error)
+		ref          string
+		assertions   func(string, error)
+	}{
+		"ResolveRef should complain when repo could not be opened": {
+			mockFunction: func(path string) (*git.Repository, error) {
+				return nil, mockError
+			},
+			ref: "main",
+			assertions: func(actual string, err error) {
+				assert.Equal(t, mockError, err)
+				assert.Equal(t, "", actual)
+			},
+		},
+		"ResolveRef should complain when ref does not exist": {
+			mockFunction: func(path string) (*git.Repository, error) {
+				repository, _ := git.Init(memory.NewStorage(), memfs.New())
+
+				createCommit(t, repository, nil)
+
+				return repository, nil
+			},
+			ref: "unknown",
+			assertions: func(actual string, err error) {
+				assert.Equal(t, plumbing.ErrReferenceNotFound, err)
+				assert.Equal(t, "", actual)
+			},
+		},
+		"ResolveRef should return the commit hash of the given ref": {
+			mockFunction: func(path string) (*git.Repository, error) {
+				repository, _ := git.Init(memory.NewStorage(), memfs.New())
+
+				hash = createCommit(t, repository, nil)
+				createCommit(t, repository, nil)
+
+				return repository, nil
+			},
+			ref: "HEAD~1",
+			assertions: func(actual string, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, hash, actual)
+			},
+		},
+	}
+
+	originalFunction := gitPlainOpen
+	defer func() { gitPlainOpen = originalFunction }()
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			gitPlainOpen = test.mockFunction
+
+			// Act
+			actual, err := ResolveRef("/mock/path", test.ref)
+
+			// Assert
+			test.assertions(actual, err)
+		})
+	}
+}
+
+func TestGetIndexContent(t *testing.T) {
+	tests := map[string]struct {
+		mockFunction func(string) (*git.Repository, error)
+		assertions   func(map[string]string, error)
+	}{
+		"GetIndexContent should complain when repo could not be opened": {
+			mockFunction: func(path string) (*git.Repository, error) {
+				return nil, mockError
+			},
+			assertions: func(content map[string]string, err error) {
+				assert.Equal(t, mockError, err)
+				assert.Len(t, content, 0)
+			},
+		},
+		"GetIndexContent should return the staged content, without untracked files": {
+			mockFunction: func(path string) (*git.Repository, error) {
+				repository, _ := git.Init(memory.NewStorage(), memfs.New())
+
+				createCommit(t, repository, map[string]string{"foo.txt": "foo content"})
+
+				worktree, _ := repository.Worktree()
+
+				file, _ := worktree.Filesystem.Create("app/bar.txt")
+				file.Write([]byte("bar content"))
+				file.Close()
+				worktree.Add("app/bar.txt")
+
+				file, _ = worktree.Filesystem.Create("untracked.txt")
+				file.Write([]byte("untracked content"))
+				file.Close()
+
+				return repository, nil
+			},
+			assertions: func(content map[string]string, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, map[string]string{
+					"app/":        "",
+					"app/bar.txt": "bar content",
+					"foo.txt":     "foo content",
+				}, content)
+			},
+		},
+	}
+
+	originalFunction := gitPlainOpen
+	defer func() { gitPlainOpen = originalFunction }()
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			gitPlainOpen = test.mockFunction
+
+			// Act
+			files, err := GetIndexContent("/mock/path")
+
+			// Assert
+			test.assertions(readFiles(t, files), err)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package skeleton
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"internal/repofs"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+// Archive is a skeleton in a `.tar.gz` (or `.tgz`) or `.zip` file, for use
+// where the skeleton repository can not be reached.
+type Archive struct {
+	Path string
+}
+
+func (a Archive) Load(ref string) (Skeleton, error) {
+	var (
+		err   error
+		files map[string]string
+	)
+
+	result := Skeleton{Ref: ref, Source: a.Path}
+
+	if ref != "" {
+		return result, fmt.Errorf("the skeleton '%s' is an archive, so it can not be read at '%s'", a.Path, ref)
+	}
+
+	if strings.HasSuffix(strings.ToLower(a.Path), ".zip") {
+		files, err = readZip(a.Path)
+	} else {
+		files, err = readTarGz(a.Path)
+	}
+
+	if err != nil {
+		return result, fmt.Errorf("could not read the skeleton archive '%s': %w", a.Path, err)
+	}
+
+	result.Files = repofs.CreateFromMap(stripTopLevel(files))
+
+	return result, nil
+}
+
+func readTarGz(archivePath string) (map[string]string, error) {
+	files := map[string]string{}
+
+	file, err := os.Open(archivePath)
+
+	if err != nil {
+		return files, err
+	}
+
+	defer func() { _ = file.Close() }()
+
+	decompressed, err := gzip.NewReader(file)
+
+	if err != nil {
+		return files, err
+	}
+
+	reader := tar.NewReader(decompressed)
+
+	for {
+		header, err := reader.Next()
+
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return files, err
+		}
+
+		if header.Typeflag == tar.TypeReg {
+			content, err := io.ReadAll(reader)
+
+			if err != nil {
+				return files, err
+			}
+
+			files[path.Clean(header.Name)] = string(content)
+		}
+	}
+
+	return files, nil
+}
+
+func readZip(archivePath string) (map[string]string, error) {
+	files := map[string]string{}
+
+	reader, err := zip.OpenReader(archivePath)
+
+	if err != nil {
+		return files, err
+	}
+
+	defer func() { _ = reader.Close() }()
+
+	for _, file := range reader.File {
+		if file.Mode().IsRegular() {
+			content, err := readZipFile(file)
+
+			if err != nil {
+				return files, err
+			}
+
+			files[path.Clean(file.Name)] = string(content)
+		}
+	}
+
+	return files, nil
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = reader.Close() }()
+
+	return io.ReadAll(reader)
+}
@@ -0,0 +1,111 @@
+package skeleton
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func createTarGz(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	archivePath := filepath.Join(t.TempDir(), "skeleton.tar.gz")
+	file, _ := os.Create(archivePath)
+	compressed := gzip.NewWriter(file)
+	writer := tar.NewWriter(compressed)
+
+	for name, content := range files {
+		assert.NoError(t, writer.WriteHeader(&tar.Header{Mode: 0o644, Name: name, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := writer.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, writer.Close())
+	assert.NoError(t, compressed.Close())
+	assert.NoError(t, file.Close())
+
+	return archivePath
+}
+
+func createZip(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	archivePath := filepath.Join(t.TempDir(), "skeleton.zip")
+	file, _ := os.Create(archivePath)
+	writer := zip.NewWriter(file)
+
+	for name, content := range files {
+		entry, err := writer.Create(name)
+		assert.NoError(t, err)
+		_, err = entry.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, writer.Close())
+	assert.NoError(t, file.Close())
+
+	return archivePath
+}
+
+func TestArchiveLoad(t *testing.T) {
+	tests := map[string]struct {
+		archive  map[string]string
+		create   func(*testing.T, map[string]string) string
+		expected map[string]string
+	}{
+		"tar.gz archive": {
+			archive:  map[string]string{"LICENSE": "mock license", ".github/FUNDING.yml": "mock funding"},
+			create:   createTarGz,
+			expected: map[string]string{"LICENSE": "mock license", ".github/FUNDING.yml": "mock funding"},
+		},
+		"tar.gz archive with a top-level directory": {
+			archive:  map[string]string{"skeleton-main/LICENSE": "mock license", "skeleton-main/.github/FUNDING.yml": "mock funding"},
+			create:   createTarGz,
+			expected: map[string]string{"LICENSE": "mock license", ".github/FUNDING.yml": "mock funding"},
+		},
+		"zip archive": {
+			archive:  map[string]string{"LICENSE": "mock license", ".github/FUNDING.yml": "mock funding"},
+			create:   createZip,
+			expected: map[string]string{"LICENSE": "mock license", ".github/FUNDING.yml": "mock funding"},
+		},
+		"zip archive with a top-level directory": {
+			archive:  map[string]string{"skeleton-main/LICENSE": "mock license", "skeleton-main/.github/FUNDING.yml": "mock funding"},
+			create:   createZip,
+			expected: map[string]string{"LICENSE": "mock license", ".github/FUNDING.yml": "mock funding"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			archivePath := test.create(t, test.archive)
+
+			actual, err := Local(archivePath).Load("")
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, readFiles(t, actual.Files))
+			assert.Equal(t, "", actual.Hash)
+			assert.Equal(t, archivePath, actual.Source)
+		})
+	}
+}
+
+func TestArchiveLoadWithRef(t *testing.T) {
+	archivePath := createZip(t, map[string]string{"LICENSE": "mock license"})
+
+	_, err := Archive{Path: archivePath}.Load("main")
+
+	assert.ErrorContains(t, err, "is an archive, so it can not be read at 'main'")
+}
+
+func TestArchiveLoadInvalid(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), "skeleton.tar.gz")
+	assert.NoError(t, os.WriteFile(archivePath, []byte("not an archive"), 0o644))
+
+	_, err := Archive{Path: archivePath}.Load("")
+
+	assert.ErrorContains(t, err, "could not read the skeleton archive")
+}
@@ -0,0 +1,128 @@
+package skeleton
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/go-git/go-git/v5"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultTTL is how long a cached skeleton repository is used, before it is
+// fetched again.
+const DefaultTTL = 24 * time.Hour
+
+// fetchedFile is touched every time a cached repository is fetched, so its
+// age can be told.
+const fetchedFile = "plc-lint-fetched"
+
+// Cache keeps a mirror of each skeleton repository on disk, so the skeleton
+// does not have to be cloned on every run, and can be used without a network.
+type Cache struct {
+	Dir string
+	// Offline only uses repositories that are already in the cache, however
+	// old they are.
+	Offline bool
+	TTL     time.Duration
+}
+
+// DefaultDir returns the directory skeleton repositories are cached in, under
+// the user cache dir (which is $XDG_CACHE_HOME on Linux).
+func DefaultDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+
+	return filepath.Join(cacheDir, "plc-lint", "skeletons"), err
+}
+
+// Load returns the files of the skeleton repository at the given URL, at the
+// given ref. The repository is cloned, or fetched when it is older than the
+// TTL, unless the cache is offline. When fetching fails, the cached copy is
+// used anyway, with a warning in the result.
+func (c Cache) Load(url string, ref string) (Skeleton, error) {
+	repository, warning, err := c.open(url)
+
+	if err != nil {
+		return Skeleton{Ref: ref, Source: url}, err
+	}
+
+	result, err := Resolve(repository, url, ref)
+	result.Warning = warning
+
+	return result, err
+}
+
+// fetchedAt returns when the given cached repository was last fetched. The
+// zero time is returned when that is not known.
+func (c Cache) fetchedAt(path string) time.Time {
+	var fetched time.Time
+
+	if info, err := os.Stat(filepath.Join(path, fetchedFile)); err == nil {
+		fetched = info.ModTime()
+	}
+
+	return fetched
+}
+
+func (c Cache) isStale(path string) bool {
+	return time.Since(c.fetchedAt(path)) >= c.TTL
+}
+
+// open returns the cached repository for the given URL. A stale repository
+// that can not be fetched is returned with a warning, rather than an error.
+func (c Cache) open(url string) (*git.Repository, string, error) {
+	var warning string
+
+	path := c.path(url)
+
+	repository, err := git.PlainOpen(path)
+
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		if c.Offline {
+			return nil, "", fmt.Errorf("the skeleton repository '%s' is not in the cache, run without --offline first", url)
+		}
+
+		repository, err = git.PlainClone(path, true, &git.CloneOptions{Mirror: true, URL: url})
+
+		if err != nil {
+			// Do not leave a partial clone behind, it would be used next time
+			_ = os.RemoveAll(path)
+		} else {
+			err = touch(path)
+		}
+	} else if err == nil && !c.Offline && c.isStale(path) {
+		fetchErr := repository.Fetch(&git.FetchOptions{Force: true, Tags: git.AllTags})
+
+		if fetchErr == nil || errors.Is(fetchErr, git.NoErrAlreadyUpToDate) {
+			err = touch(path)
+		} else {
+			// An outdated skeleton is better than none, for instance without a network
+			warning = fmt.Sprintf(
+				"could not update the cached skeleton repository '%s', the copy fetched at %s is used: %v",
+				url,
+				c.fetchedAt(path).Format(time.DateTime),
+				fetchErr,
+			)
+		}
+	}
+
+	if err != nil {
+		return nil, "", fmt.Errorf("could not update the cached skeleton repository '%s': %w", url, err)
+	}
+
+	return repository, warning, nil
+}
+
+// path returns where the given repository is cached. The URL is hashed, so it
+// can be used as a directory name.
+func (c Cache) path(url string) string {
+	hash := sha256.Sum256([]byte(url))
+
+	return filepath.Join(c.Dir, hex.EncodeToString(hash[:]))
+}
+
+func touch(path string) error {
+	return os.WriteFile(filepath.Join(path, fetchedFile), []byte(time.Now().UTC().Format(time.RFC3339)+"\n"), 0o644)
+}
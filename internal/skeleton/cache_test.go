@@ -0,0 +1,98 @@
+package skeleton
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCacheLoad(t *testing.T) {
+	source := t.TempDir()
+	firstHash := createCommit(t, source, map[string]string{"LICENSE": "first"})
+
+	cache := Cache{Dir: t.TempDir(), TTL: time.Hour}
+
+	actual, err := cache.Load(source, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, firstHash, actual.Hash)
+	assert.Equal(t, map[string]string{"LICENSE": "first"}, readFiles(t, actual.Files))
+	assert.FileExists(t, filepath.Join(cache.path(source), fetchedFile))
+
+	secondHash := createCommit(t, source, map[string]string{"LICENSE": "second"})
+
+	// Within the TTL the cached repository is used as it is
+	actual, err = cache.Load(source, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, firstHash, actual.Hash)
+
+	// An offline cache is never fetched
+	expired := time.Now().Add(-2 * time.Hour)
+	assert.NoError(t, os.Chtimes(filepath.Join(cache.path(source), fetchedFile), expired, expired))
+
+	cache.Offline = true
+	actual, err = cache.Load(source, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, firstHash, actual.Hash)
+
+	// Once the TTL has passed, the cached repository is fetched
+	cache.Offline = false
+	actual, err = cache.Load(source, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, secondHash, actual.Hash)
+	assert.Equal(t, map[string]string{"LICENSE": "second"}, readFiles(t, actual.Files))
+
+	actual, err = cache.Load(source, firstHash[:7])
+
+	assert.NoError(t, err)
+	assert.Equal(t, firstHash, actual.Hash)
+}
+
+func TestCacheLoadOfflineWithoutCache(t *testing.T) {
+	source := t.TempDir()
+	createCommit(t, source, map[string]string{"LICENSE": "first"})
+
+	cache := Cache{Dir: t.TempDir(), Offline: true, TTL: time.Hour}
+
+	_, err := cache.Load(source, "")
+
+	assert.ErrorContains(t, err, "is not in the cache, run without --offline first")
+	assert.NoDirExists(t, cache.path(source))
+}
+
+func TestCacheLoadStaleUnreachable(t *testing.T) {
+	source := t.TempDir()
+	hash := createCommit(t, source, map[string]string{"LICENSE": "first"})
+
+	cache := Cache{Dir: t.TempDir(), TTL: time.Hour}
+
+	_, err := cache.Load(source, "")
+
+	assert.NoError(t, err)
+
+	expired := time.Now().Add(-2 * time.Hour)
+	assert.NoError(t, os.Chtimes(filepath.Join(cache.path(source), fetchedFile), expired, expired))
+	assert.NoError(t, os.RemoveAll(source))
+
+	// The stale copy is used when it can not be fetched
+	actual, err := cache.Load(source, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, hash, actual.Hash)
+	assert.Contains(t, actual.Warning, "could not update the cached skeleton repository '"+source+"', the copy fetched at "+expired.Format(time.DateTime)+" is used")
+}
+
+func TestCacheLoadUnreachable(t *testing.T) {
+	cache := Cache{Dir: t.TempDir(), TTL: time.Hour}
+	source := filepath.Join(t.TempDir(), "missing")
+
+	_, err := cache.Load(source, "")
+
+	assert.ErrorContains(t, err, "could not update the cached skeleton repository")
+	assert.NoDirExists(t, cache.path(source))
+}
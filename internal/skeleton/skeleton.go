@@ -0,0 +1,71 @@
+package skeleton
+
+import (
+	"fmt"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"internal/repofs"
+	"internal/repositorycontents"
+)
+
+// Skeleton holds the files of a skeleton repository, together with where they
+// were taken from.
+type Skeleton struct {
+	Files repofs.RepoFS
+	// Hash is the commit the files were taken from. It is empty when the
+	// skeleton is read from a directory or an archive, rather than a commit.
+	Hash string
+	// Ref is the branch, tag or commit that was asked for. It is empty when the
+	// default branch is used.
+	Ref    string
+	Source string
+	// Warning tells why the files may not be up to date, empty when they are
+	Warning string
+}
+
+// Resolve returns the files of the given repository at the given ref, which
+// can be a branch, a tag or a (short) commit hash. An empty ref uses HEAD.
+func Resolve(repository *git.Repository, source string, ref string) (Skeleton, error) {
+	result := Skeleton{Ref: ref, Source: source}
+
+	revision := ref
+
+	if revision == "" {
+		revision = "HEAD"
+	}
+
+	hash, err := repository.ResolveRevision(plumbing.Revision(revision))
+
+	if err != nil {
+		return result, fmt.Errorf("could not find '%s' in the skeleton repository '%s': %w", revision, source, err)
+	}
+
+	commit, err := repository.CommitObject(*hash)
+
+	if err == nil {
+		result.Files, err = repositorycontents.GetCommitContent(commit)
+	}
+
+	if err != nil {
+		return result, fmt.Errorf("could not get content from '%s': %w", source, err)
+	}
+
+	result.Hash = hash.String()
+
+	return result, nil
+}
+
+// String describes where the skeleton was taken from, for the report
+func (s Skeleton) String() string {
+	description := s.Source
+
+	if s.Ref != "" {
+		description = fmt.Sprintf("%s (%s)", description, s.Ref)
+	}
+
+	if s.Hash != "" {
+		description = fmt.Sprintf("%s at %s", description, s.Hash)
+	}
+
+	return description
+}
@@ -0,0 +1,119 @@
+package skeleton
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"internal/repofs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var mockSignature = &object.Signature{
+	Name:  "Mock Author",
+	Email: "mock@example.com",
+	When:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+}
+
+// readFiles reads the files (but not the directories) into a map, for easy
+// comparison.
+func readFiles(t *testing.T, files repofs.RepoFS) map[string]string {
+	t.Helper()
+
+	content := map[string]string{}
+
+	for _, name := range files.List() {
+		if !files.IsDir(name) {
+			content[name], _ = files.ReadFile(name)
+		}
+	}
+
+	return content
+}
+
+func createCommit(t *testing.T, path string, files map[string]string) string {
+	t.Helper()
+
+	repository, err := git.PlainOpen(path)
+
+	if err != nil {
+		repository, err = git.PlainInit(path, false)
+	}
+
+	assert.NoError(t, err)
+
+	worktree, _ := repository.Worktree()
+
+	for fileName, content := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(path, fileName), []byte(content), 0o644))
+		_, err = worktree.Add(fileName)
+		assert.NoError(t, err)
+	}
+
+	hash, err := worktree.Commit("Commit message", &git.CommitOptions{Author: mockSignature})
+
+	assert.NoError(t, err)
+
+	return hash.String()
+}
+
+func TestResolve(t *testing.T) {
+	source := t.TempDir()
+
+	firstHash := createCommit(t, source, map[string]string{"LICENSE": "first"})
+	repository, _ := git.PlainOpen(source)
+	head, _ := repository.Head()
+	_, _ = repository.CreateTag("v1.0.0", head.Hash(), &git.CreateTagOptions{Message: "v1.0.0", Tagger: mockSignature})
+	secondHash := createCommit(t, source, map[string]string{"LICENSE": "second"})
+
+	tests := map[string]struct {
+		content string
+		hash    string
+		ref     string
+	}{
+		"Default branch":    {content: "second", hash: secondHash, ref: ""},
+		"Annotated tag":     {content: "first", hash: firstHash, ref: "v1.0.0"},
+		"Full commit hash":  {content: "first", hash: firstHash, ref: firstHash},
+		"Short commit hash": {content: "first", hash: firstHash, ref: firstHash[:7]},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := Resolve(repository, source, test.ref)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.hash, actual.Hash)
+			assert.Equal(t, map[string]string{"LICENSE": test.content}, readFiles(t, actual.Files))
+		})
+	}
+}
+
+func TestResolveUnknownRef(t *testing.T) {
+	source := t.TempDir()
+
+	createCommit(t, source, map[string]string{"LICENSE": "first"})
+	repository, _ := git.PlainOpen(source)
+
+	_, err := Resolve(repository, source, "unknown")
+
+	assert.ErrorContains(t, err, "could not find 'unknown' in the skeleton repository")
+}
+
+func TestString(t *testing.T) {
+	tests := map[string]struct {
+		expected string
+		skeleton Skeleton
+	}{
+		"Directory":       {expected: "/path/to/skeleton", skeleton: Skeleton{Source: "/path/to/skeleton"}},
+		"Default branch":  {expected: "https://example.com/skeleton.git at 0123abcd", skeleton: Skeleton{Hash: "0123abcd", Source: "https://example.com/skeleton.git"}},
+		"Pinned to a ref": {expected: "https://example.com/skeleton.git (v1.0.0) at 0123abcd", skeleton: Skeleton{Hash: "0123abcd", Ref: "v1.0.0", Source: "https://example.com/skeleton.git"}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.skeleton.String())
+		})
+	}
+}
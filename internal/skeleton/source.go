@@ -0,0 +1,119 @@
+package skeleton
+
+import (
+	"fmt"
+	"github.com/go-git/go-git/v5"
+	"internal/directorylist"
+	"internal/repofs"
+	"strings"
+)
+
+// Source is a place a skeleton can be loaded from
+type Source interface {
+	// Load returns the files of the skeleton at the given ref (a branch, tag or
+	// commit). An empty ref uses the default branch. Sources that are not a
+	// repository do not accept a ref.
+	Load(ref string) (Skeleton, error)
+}
+
+// Directory is a skeleton that is read from a directory as it is, untracked
+// files included. Files that git ignores are left out.
+type Directory struct {
+	Path string
+}
+
+// Remote is a skeleton repository that is cloned from a URL, through the cache
+type Remote struct {
+	Cache Cache
+	URL   string
+}
+
+// Repository is a git repository on disk. It is read at a ref (HEAD when none
+// is given) without checking anything out, so uncommitted changes in the
+// working tree are left out. Use a Directory to read a working tree.
+type Repository struct {
+	Path string
+}
+
+// Local returns the source for a skeleton on disk, based on what the given path
+// points to: a `.tar.gz` or `.zip` archive, a git repository or a directory.
+func Local(path string) Source {
+	var source Source
+
+	if isArchive(path) {
+		source = Archive{Path: path}
+	} else if _, err := git.PlainOpen(path); err == nil {
+		source = Repository{Path: path}
+	} else {
+		source = Directory{Path: path}
+	}
+
+	return source
+}
+
+func (d Directory) Load(ref string) (Skeleton, error) {
+	var err error
+
+	result := Skeleton{Ref: ref, Source: d.Path}
+
+	if ref != "" {
+		return result, fmt.Errorf("the skeleton '%s' is not a git repository, so it can not be read at '%s'", d.Path, ref)
+	}
+
+	result.Files, err = repofs.CreateFromDirectory(d.Path, directorylist.Options{})
+
+	if err != nil {
+		return result, fmt.Errorf("could not read files from '%s': %w", d.Path, err)
+	}
+
+	return result, nil
+}
+
+func (r Remote) Load(ref string) (Skeleton, error) {
+	return r.Cache.Load(r.URL, ref)
+}
+
+func (r Repository) Load(ref string) (Skeleton, error) {
+	repository, err := git.PlainOpen(r.Path)
+
+	if err != nil {
+		return Skeleton{Ref: ref, Source: r.Path}, fmt.Errorf("could not open the skeleton repository '%s': %w", r.Path, err)
+	}
+
+	return Resolve(repository, r.Path, ref)
+}
+
+func isArchive(path string) bool {
+	for _, extension := range []string{".tar.gz", ".tgz", ".zip"} {
+		if strings.HasSuffix(strings.ToLower(path), extension) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// stripTopLevel removes the directory all files are in, if there is exactly
+// one, as archives downloaded from a forge put everything in a directory named
+// after the project and ref.
+func stripTopLevel(files map[string]string) map[string]string {
+	var topLevel string
+
+	for name := range files {
+		directory, _, found := strings.Cut(name, "/")
+
+		if !found || (topLevel != "" && directory != topLevel) {
+			return files
+		}
+
+		topLevel = directory
+	}
+
+	stripped := map[string]string{}
+
+	for name, content := range files {
+		stripped[strings.TrimPrefix(name, topLevel+"/")] = content
+	}
+
+	return stripped
+}
@@ -0,0 +1,77 @@
+package skeleton
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocal(t *testing.T) {
+	repositoryPath := t.TempDir()
+	_, _ = git.PlainInit(repositoryPath, false)
+
+	tests := map[string]struct {
+		expected Source
+		path     string
+	}{
+		"tar.gz archive": {expected: Archive{Path: "skeleton.tar.gz"}, path: "skeleton.tar.gz"},
+		"tgz archive":    {expected: Archive{Path: "skeleton.tgz"}, path: "skeleton.tgz"},
+		"zip archive":    {expected: Archive{Path: "skeleton.ZIP"}, path: "skeleton.ZIP"},
+		"git repository": {expected: Repository{Path: repositoryPath}, path: repositoryPath},
+		"directory":      {expected: Directory{Path: "skeleton"}, path: "skeleton"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Local(test.path))
+		})
+	}
+}
+
+func TestDirectoryLoad(t *testing.T) {
+	directory := t.TempDir()
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(directory, ".github"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(directory, ".github", "FUNDING.yml"), []byte("mock funding"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(directory, "LICENSE"), []byte("mock license"), 0o644))
+
+	actual, err := Directory{Path: directory}.Load("")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{".github/FUNDING.yml": "mock funding", "LICENSE": "mock license"}, readFiles(t, actual.Files))
+
+	_, err = Directory{Path: directory}.Load("main")
+
+	assert.ErrorContains(t, err, "is not a git repository, so it can not be read at 'main'")
+}
+
+func TestRepositoryLoad(t *testing.T) {
+	repositoryPath := t.TempDir()
+
+	firstHash := createCommit(t, repositoryPath, map[string]string{"LICENSE": "first"})
+	secondHash := createCommit(t, repositoryPath, map[string]string{"LICENSE": "second"})
+
+	// Uncommitted changes are not part of the skeleton, HEAD is read without a ref
+	assert.NoError(t, os.WriteFile(filepath.Join(repositoryPath, "LICENSE"), []byte("uncommitted"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(repositoryPath, "untracked"), []byte("untracked"), 0o644))
+
+	actual, err := Repository{Path: repositoryPath}.Load("")
+
+	assert.NoError(t, err)
+	assert.Equal(t, secondHash, actual.Hash)
+	assert.Equal(t, map[string]string{"LICENSE": "second"}, readFiles(t, actual.Files))
+
+	actual, err = Repository{Path: repositoryPath}.Load("HEAD")
+
+	assert.NoError(t, err)
+	assert.Equal(t, secondHash, actual.Hash)
+	assert.Equal(t, map[string]string{"LICENSE": "second"}, readFiles(t, actual.Files))
+
+	actual, err = Repository{Path: repositoryPath}.Load(firstHash)
+
+	assert.NoError(t, err)
+	assert.Equal(t, firstHash, actual.Hash)
+	assert.Equal(t, map[string]string{"LICENSE": "first"}, readFiles(t, actual.Files))
+}
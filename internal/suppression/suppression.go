@@ -0,0 +1,130 @@
+package suppression
+
+import (
+	"fmt"
+	"internal/check"
+	"internal/message"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// FileName is the name of the suppression file in the root of a component
+const FileName = ".plc-lint-ignore"
+
+var (
+	codePattern = regexp.MustCompile(`^PLC\d{4,5}$`)
+	datePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	// The PLC22 codes report on the suppressions, so they can not be suppressed
+	ownCodePattern = regexp.MustCompile(`^PLC22\d{3}$`)
+)
+
+// Suppression marks the failures of a single code as deliberate. A
+// suppression without an expiry date never expires.
+type Suppression struct {
+	Code    string
+	Expires time.Time
+	Line    int
+	Reason  string
+}
+
+// Problem is a line in the suppression file that could not be read
+type Problem struct {
+	Line    int
+	Message string
+}
+
+// Parse reads suppressions from the given content. Each line holds a code,
+// an optional expiry date (as YYYY-MM-DD) and the reason for the suppression:
+//
+//	PLC6001 2025-12-31 The build output folder is ignored as well
+//
+// Blank lines and lines starting with `#` are ignored. Lines that can not be
+// read are returned as problems, all other lines as suppressions.
+func Parse(content string) ([]Suppression, []Problem) {
+	var (
+		problems     []Problem
+		suppressions []Suppression
+	)
+
+	for index, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		suppression := Suppression{Code: fields[0], Line: index + 1}
+		reason := fields[1:]
+
+		if len(reason) > 0 && datePattern.MatchString(reason[0]) {
+			expires, err := time.Parse(time.DateOnly, reason[0])
+
+			if err != nil {
+				problems = append(problems, Problem{Line: index + 1, Message: fmt.Sprintf("'%s' is not a valid date", reason[0])})
+				continue
+			}
+
+			suppression.Expires = expires
+			reason = reason[1:]
+		}
+
+		suppression.Reason = strings.Join(reason, " ")
+
+		if !codePattern.MatchString(suppression.Code) {
+			problems = append(problems, Problem{Line: index + 1, Message: fmt.Sprintf("'%s' is not a PLC code", suppression.Code)})
+		} else if !IsSuppressible(suppression.Code) {
+			problems = append(problems, Problem{Line: index + 1, Message: fmt.Sprintf("%s reports on the suppressions themselves, so it can not be suppressed", suppression.Code)})
+		} else if suppression.Reason == "" {
+			problems = append(problems, Problem{Line: index + 1, Message: fmt.Sprintf("The suppression of %s MUST give a reason", suppression.Code)})
+		} else {
+			suppressions = append(suppressions, suppression)
+		}
+	}
+
+	return suppressions, problems
+}
+
+// IsSuppressible tells whether the failures of the given code can be
+// suppressed, which is all codes but those of PLC22.
+func IsSuppressible(code string) bool {
+	return !ownCodePattern.MatchString(code)
+}
+
+// IsExpired tells whether the suppression no longer applies at the given
+// time. A suppression is valid up to, and including, its expiry date.
+func (s Suppression) IsExpired(now time.Time) bool {
+	return !s.Expires.IsZero() && !now.Before(s.Expires.AddDate(0, 0, 1))
+}
+
+// Apply marks the failures that are suppressed (and not expired) as such. The
+// suppressions that did not match any failure are returned as unused. The
+// failures of codes that can not be suppressed are left as they are.
+func Apply(messages []message.Message, suppressions []Suppression, now time.Time) ([]message.Message, []Suppression) {
+	var unused []Suppression
+
+	applied := append([]message.Message{}, messages...)
+
+	for _, suppression := range suppressions {
+		if suppression.IsExpired(now) {
+			continue
+		}
+
+		used := false
+
+		for index, checkMessage := range applied {
+			if checkMessage.Code == suppression.Code && checkMessage.Status == check.Fail && IsSuppressible(checkMessage.Code) {
+				applied[index].Reason = suppression.Reason
+				applied[index].Status = check.Suppressed
+				used = true
+			}
+		}
+
+		if !used {
+			unused = append(unused, suppression)
+		}
+	}
+
+	return applied, unused
+}
@@ -0,0 +1,97 @@
+package suppression
+
+import (
+	"github.com/stretchr/testify/assert"
+	"internal/check"
+	"internal/message"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		content      string
+		problems     []Problem
+		suppressions []Suppression
+	}{
+		"Empty file": {
+			content: "",
+		},
+		"Comments and blank lines": {
+			content: "# A comment\n\n   \n",
+		},
+		"Suppression without expiry date": {
+			content:      "PLC6001 The build output folder is ignored as well\n",
+			suppressions: []Suppression{{Code: "PLC6001", Line: 1, Reason: "The build output folder is ignored as well"}},
+		},
+		"Suppression with expiry date": {
+			content: "# Header\nPLC13003  2025-12-31  Support is handled by the helpdesk\n",
+			suppressions: []Suppression{{
+				Code:    "PLC13003",
+				Expires: time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+				Line:    2,
+				Reason:  "Support is handled by the helpdesk",
+			}},
+		},
+		"Invalid lines": {
+			content: "PLC6001\nPLC6002 2025-12-31\nPLC6\n.gitignore Reason\nPLC6003 2025-13-01 Reason\nPLC6004 Reason\nPLC22003 Reason\n",
+			problems: []Problem{
+				{Line: 1, Message: "The suppression of PLC6001 MUST give a reason"},
+				{Line: 2, Message: "The suppression of PLC6002 MUST give a reason"},
+				{Line: 3, Message: "'PLC6' is not a PLC code"},
+				{Line: 4, Message: "'.gitignore' is not a PLC code"},
+				{Line: 5, Message: "'2025-13-01' is not a valid date"},
+				{Line: 7, Message: "PLC22003 reports on the suppressions themselves, so it can not be suppressed"},
+			},
+			suppressions: []Suppression{{Code: "PLC6004", Line: 6, Reason: "Reason"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			suppressions, problems := Parse(test.content)
+
+			assert.Equal(t, test.problems, problems)
+			assert.Equal(t, test.suppressions, suppressions)
+		})
+	}
+}
+
+func TestIsExpired(t *testing.T) {
+	suppression := Suppression{Code: "PLC6001", Expires: time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)}
+
+	assert.False(t, Suppression{Code: "PLC6001"}.IsExpired(time.Now()))
+	assert.False(t, suppression.IsExpired(time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC)))
+	assert.True(t, suppression.IsExpired(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestApply(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	messages := []message.Message{
+		message.CreateMessage(check.Fail, "PLC6001", "Mock message 6001"),
+		message.CreateMessage(check.Fail, "PLC6001", "Another mock message 6001"),
+		message.CreateMessage(check.Pass, "PLC6002", "Mock message 6002"),
+		message.CreateMessage(check.Fail, "PLC7001", "Mock message 7001"),
+		message.CreateMessage(check.Fail, "PLC22001", "Mock message 22001"),
+	}
+
+	suppressions := []Suppression{
+		{Code: "PLC6001", Line: 1, Reason: "Mock reason 6001"},
+		{Code: "PLC6002", Line: 2, Reason: "Mock reason 6002"},
+		{Code: "PLC7001", Expires: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Line: 3, Reason: "Mock reason 7001"},
+		{Code: "PLC22001", Line: 4, Reason: "Mock reason 22001"},
+	}
+
+	applied, unused := Apply(messages, suppressions, now)
+
+	expected := map[int]check.Status{0: check.Suppressed, 1: check.Suppressed, 2: check.Pass, 3: check.Fail, 4: check.Fail}
+
+	for index, status := range expected {
+		assert.Equal(t, status, applied[index].Status, "%s expected status %v, got %v", applied[index].Code, status, applied[index].Status)
+	}
+
+	assert.Equal(t, "Mock reason 6001", applied[0].Reason)
+	assert.Equal(t, check.Fail, messages[0].Status)
+	assert.Equal(t, []Suppression{suppressions[1], suppressions[3]}, unused)
+}
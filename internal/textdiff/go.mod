@@ -0,0 +1,13 @@
+module textdiff
+
+go 1.22
+
+require (
+	github.com/pmezard/go-difflib v1.0.0
+	github.com/stretchr/testify v1.9.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,64 @@
+package textdiff
+
+import (
+	"github.com/pmezard/go-difflib/difflib"
+	"strings"
+	"unicode"
+)
+
+// WhitespaceOnlyNote is added to a message when the texts it is about only
+// differ in whitespace, as that is easy to miss in a diff.
+const WhitespaceOnlyNote = " (only whitespace differs)"
+
+// IsWhitespaceOnly tells whether two texts are different, but the same when
+// all whitespace is left out.
+func IsWhitespaceOnly(from string, to string) bool {
+	withoutWhitespace := func(text string) string {
+		return strings.Map(func(character rune) rune {
+			if unicode.IsSpace(character) {
+				return -1
+			}
+
+			return character
+		}, text)
+	}
+
+	return from != to && withoutWhitespace(from) == withoutWhitespace(to)
+}
+
+// Unified returns the changes needed to get from one text to the other, as a
+// unified diff with three lines of context. An empty string is returned when
+// the texts are the same.
+func Unified(fromName string, from string, toName string, to string) string {
+	if from == to {
+		return ""
+	}
+
+	unified, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(from),
+		B:        splitLines(to),
+		Context:  3,
+		FromFile: fromName,
+		ToFile:   toName,
+	})
+
+	return unified
+}
+
+// splitLines keeps the line endings, so a missing newline at the end of a
+// text is shown as a difference, and an empty text has no lines at all.
+func splitLines(text string) []string {
+	var lines []string
+
+	if text != "" {
+		lines = strings.SplitAfter(text, "\n")
+
+		if lines[len(lines)-1] == "" {
+			lines = lines[:len(lines)-1]
+		} else {
+			lines[len(lines)-1] += "\n\\ No newline at end of file\n"
+		}
+	}
+
+	return lines
+}
@@ -0,0 +1,63 @@
+package textdiff
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	tests := map[string]struct {
+		expected string
+		from     string
+		to       string
+	}{
+		"Same text": {
+			expected: "",
+			from:     "line 1\nline 2\n",
+			to:       "line 1\nline 2\n",
+		},
+		"Changed line": {
+			expected: "--- a\n+++ b\n@@ -1,3 +1,3 @@\n line 1\n-line 2\n+line two\n line 3\n",
+			from:     "line 1\nline 2\nline 3\n",
+			to:       "line 1\nline two\nline 3\n",
+		},
+		"New text": {
+			expected: "--- a\n+++ b\n@@ -0,0 +1,2 @@\n+line 1\n+line 2\n",
+			from:     "",
+			to:       "line 1\nline 2\n",
+		},
+		"Missing newline at end": {
+			expected: "--- a\n+++ b\n@@ -1 +1 @@\n-line 1\n\\ No newline at end of file\n+line 1\n",
+			from:     "line 1",
+			to:       "line 1\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Unified("a", test.from, "b", test.to))
+		})
+	}
+}
+
+func TestIsWhitespaceOnly(t *testing.T) {
+	tests := map[string]struct {
+		expected bool
+		from     string
+		to       string
+	}{
+		"Same text":             {expected: false, from: "line 1\n", to: "line 1\n"},
+		"Different text":        {expected: false, from: "line 1\n", to: "line 2\n"},
+		"Trailing whitespace":   {expected: true, from: "line 1  \n", to: "line 1\n"},
+		"Missing newline":       {expected: true, from: "line 1", to: "line 1\n"},
+		"Tabs instead of space": {expected: true, from: "\tline 1\n", to: "  line 1\n"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual := IsWhitespaceOnly(test.from, test.to)
+
+			assert.Equal(t, test.expected, actual, "%s expected %v, got %v", name, test.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,410 @@
+package yamllint
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"internal/repofs"
+	"path"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// The configurations that are built into yamllint, which can be extended from
+const defaultPreset = `
+yaml-files:
+  - '*.yaml'
+  - '*.yml'
+  - '.yamllint'
+
+rules:
+  anchors: enable
+  braces: enable
+  brackets: enable
+  colons: enable
+  commas: enable
+  comments:
+    level: warning
+  comments-indentation:
+    level: warning
+  document-end: disable
+  document-start:
+    level: warning
+  empty-lines: enable
+  empty-values: disable
+  float-values: disable
+  hyphens: enable
+  indentation: enable
+  key-duplicates: enable
+  key-ordering: disable
+  line-length: enable
+  new-line-at-end-of-file: enable
+  new-lines: enable
+  octal-values: disable
+  quoted-strings: disable
+  trailing-spaces: enable
+  truthy:
+    level: warning
+`
+
+const relaxedPreset = `
+extends: default
+
+rules:
+  braces:
+    level: warning
+    max-spaces-inside: 1
+  brackets:
+    level: warning
+    max-spaces-inside: 1
+  colons:
+    level: warning
+  commas:
+    level: warning
+  comments: disable
+  comments-indentation: disable
+  document-start: disable
+  empty-lines:
+    level: warning
+  hyphens:
+    level: warning
+  indentation:
+    level: warning
+    indent-sequences: consistent
+  line-length:
+    level: warning
+    allow-non-breakable-inline-mappings: true
+  truthy: disable
+`
+
+type ruleConfig struct {
+	ignore  []string
+	level   string
+	options options
+}
+
+// Config holds the rules that are enabled, with their level and options, and
+// the files they apply to, as defined by a `.yamllint` file.
+type Config struct {
+	ignore    []string
+	rules     map[string]ruleConfig
+	yamlFiles []string
+}
+
+type rawConfig struct {
+	Extends        string               `yaml:"extends"`
+	Ignore         interface{}          `yaml:"ignore"`
+	IgnoreFromFile interface{}          `yaml:"ignore-from-file"`
+	Locale         string               `yaml:"locale"`
+	Rules          map[string]yaml.Node `yaml:"rules"`
+	YamlFiles      []string             `yaml:"yaml-files"`
+}
+
+// DefaultConfig returns the configuration yamllint uses when no `.yamllint`
+// file is present.
+func DefaultConfig() Config {
+	config, _ := ParseConfig("extends: default", nil)
+
+	return config
+}
+
+func toList(value interface{}) ([]string, error) {
+	var (
+		err  error
+		list []string
+	)
+
+	switch value := value.(type) {
+	case nil:
+	case string:
+		list = strings.Split(value, "\n")
+	case []interface{}:
+		for _, item := range value {
+			if text, ok := item.(string); ok {
+				list = append(list, text)
+			} else {
+				err = fmt.Errorf("invalid pattern %v, expected a string", item)
+			}
+		}
+	default:
+		err = fmt.Errorf("invalid patterns %v, expected a string or a list of strings", value)
+	}
+
+	return list, err
+}
+
+func isValidOption(value interface{}, types []interface{}) bool {
+	valid := false
+
+	for _, allowed := range types {
+		if text, ok := allowed.(string); ok {
+			valid = value == text
+		} else {
+			valid = reflect.TypeOf(value) == reflect.TypeOf(allowed)
+		}
+
+		if valid {
+			break
+		}
+	}
+
+	return valid
+}
+
+func getRule(id string) (rule, bool) {
+	index := slices.IndexFunc(rules, func(candidate rule) bool {
+		return candidate.id == id
+	})
+
+	if index < 0 {
+		return rule{}, false
+	}
+
+	return rules[index], true
+}
+
+func parseRuleConfig(id string, node yaml.Node, base ruleConfig, enabled bool) (ruleConfig, bool, error) {
+	var (
+		err   error
+		value interface{}
+	)
+
+	ruleDefinition, ok := getRule(id)
+
+	if !ok {
+		return base, enabled, fmt.Errorf("no such rule: \"%s\"", id)
+	}
+
+	if err = node.Decode(&value); err != nil {
+		return base, enabled, err
+	}
+
+	config := ruleConfig{level: "error", options: options{}}
+
+	for name, defaultValue := range ruleDefinition.defaults {
+		config.options[name] = defaultValue
+	}
+
+	switch value := value.(type) {
+	case string:
+		switch value {
+		case "enable":
+			enabled = true
+		case "disable":
+			enabled = false
+		default:
+			err = fmt.Errorf("invalid config: rule \"%s\": should be either \"enable\", \"disable\" or a mapping", id)
+		}
+
+	case map[string]interface{}:
+		// Options that are given extend those of the configuration that is extended
+		if enabled {
+			config = base
+			config.options = options{}
+
+			for name, optionValue := range base.options {
+				config.options[name] = optionValue
+			}
+		}
+
+		enabled = true
+
+		for name, optionValue := range value {
+			switch name {
+			case "ignore":
+				config.ignore, err = toList(optionValue)
+			case "ignore-from-file":
+				err = fmt.Errorf("invalid config: rule \"%s\": \"ignore-from-file\" is not supported for rules", id)
+			case "level":
+				if optionValue != "error" && optionValue != "warning" {
+					err = fmt.Errorf("invalid config: level should be \"error\" or \"warning\"")
+				}
+
+				config.level, _ = optionValue.(string)
+			default:
+				types, known := ruleDefinition.types[name]
+
+				if !known {
+					err = fmt.Errorf("invalid config: unknown option \"%s\" for rule \"%s\"", name, id)
+				} else if !isValidOption(optionValue, types) {
+					err = fmt.Errorf("invalid config: option \"%s\" of \"%s\" should be in %v", name, id, types)
+				}
+
+				config.options[name] = optionValue
+			}
+
+			if err != nil {
+				break
+			}
+		}
+
+	default:
+		err = fmt.Errorf("invalid config: rule \"%s\": should be either \"enable\", \"disable\" or a mapping", id)
+	}
+
+	return config, enabled, err
+}
+
+// ParseConfig reads the given `.yamllint` content. Configurations that are
+// extended are either one of the presets (`default`, `relaxed`) or are read
+// from the repository files.
+func ParseConfig(content string, files repofs.RepoFS) (Config, error) {
+	return parseConfig(content, files, 0)
+}
+
+func parseConfig(content string, files repofs.RepoFS, depth int) (Config, error) {
+	var (
+		err error
+		raw rawConfig
+	)
+
+	config := Config{rules: map[string]ruleConfig{}}
+
+	if err = yaml.Unmarshal([]byte(content), &raw); err != nil {
+		return config, fmt.Errorf("invalid config: %w", err)
+	}
+
+	if raw.Extends != "" {
+		if depth > 10 {
+			return config, fmt.Errorf("invalid config: too many levels of \"extends\"")
+		}
+
+		switch raw.Extends {
+		case "default":
+			config, err = parseConfig(defaultPreset, files, depth+1)
+		case "relaxed":
+			config, err = parseConfig(relaxedPreset, files, depth+1)
+		default:
+			if extended, readErr := files.ReadFile(strings.TrimPrefix(raw.Extends, "./")); readErr == nil {
+				config, err = parseConfig(extended, files, depth+1)
+			} else {
+				err = fmt.Errorf("invalid config: the extended configuration \"%s\" could not be found", raw.Extends)
+			}
+		}
+
+		if err != nil {
+			return config, err
+		}
+	}
+
+	if raw.Ignore != nil && raw.IgnoreFromFile != nil {
+		return config, fmt.Errorf("invalid config: \"ignore\" and \"ignore-from-file\" keys cannot be used together")
+	}
+
+	if raw.Ignore != nil {
+		if config.ignore, err = toList(raw.Ignore); err != nil {
+			return config, fmt.Errorf("invalid config: %w", err)
+		}
+	}
+
+	if raw.IgnoreFromFile != nil {
+		ignoreFiles, err := toList(raw.IgnoreFromFile)
+
+		if err != nil {
+			return config, fmt.Errorf("invalid config: %w", err)
+		}
+
+		config.ignore = nil
+
+		for _, ignoreFile := range ignoreFiles {
+			// An ignore file that can not be read does not ignore anything
+			ignored, _ := files.ReadFile(ignoreFile)
+			config.ignore = append(config.ignore, strings.Split(ignored, "\n")...)
+		}
+	}
+
+	if raw.YamlFiles != nil {
+		config.yamlFiles = raw.YamlFiles
+	}
+
+	ids := make([]string, 0, len(raw.Rules))
+
+	for id := range raw.Rules {
+		ids = append(ids, id)
+	}
+
+	slices.Sort(ids)
+
+	for _, id := range ids {
+		base, enabled := config.rules[id]
+
+		ruleConfig, enabled, err := parseRuleConfig(id, raw.Rules[id], base, enabled)
+
+		if err != nil {
+			return config, err
+		}
+
+		if enabled {
+			config.rules[id] = ruleConfig
+		} else {
+			delete(config.rules, id)
+		}
+	}
+
+	return config, nil
+}
+
+func compilePattern(pattern string) (*regexp.Regexp, bool) {
+	negated := strings.HasPrefix(pattern, "!")
+	pattern = strings.TrimPrefix(pattern, "!")
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	expression := ""
+
+	for index := 0; index < len(pattern); index++ {
+		switch {
+		case strings.HasPrefix(pattern[index:], "**/"):
+			expression += "(?:.*/)?"
+			index += 2
+		case strings.HasPrefix(pattern[index:], "**"):
+			expression += ".*"
+			index++
+		case pattern[index] == '*':
+			expression += "[^/]*"
+		case pattern[index] == '?':
+			expression += "[^/]"
+		default:
+			expression += regexp.QuoteMeta(pattern[index : index+1])
+		}
+	}
+
+	expression = strings.TrimSuffix(expression, "/")
+
+	if anchored {
+		expression = "^" + expression
+	} else {
+		expression = "(?:^|/)" + expression
+	}
+
+	return regexp.MustCompile(expression + "(?:/.*)?$"), negated
+}
+
+// matchesPatterns tells whether the given path is matched by the given
+// `.gitignore` style patterns.
+func matchesPatterns(filePath string, patterns []string) bool {
+	matched := false
+
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+
+		expression, negated := compilePattern(pattern)
+
+		if expression.MatchString(filePath) {
+			matched = !negated
+		}
+	}
+
+	return matched
+}
+
+// IsYamlFile tells whether the given path should be linted, based on the
+// `yaml-files` and `ignore` settings.
+func (c Config) IsYamlFile(filePath string) bool {
+	return matchesPatterns(path.Clean(filePath), c.yamlFiles) && !matchesPatterns(path.Clean(filePath), c.ignore)
+}
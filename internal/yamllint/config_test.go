@@ -0,0 +1,105 @@
+package yamllint
+
+import (
+	"github.com/stretchr/testify/assert"
+	"internal/repofs"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		error   string
+		files   map[string]string
+		rules   map[string]string
+	}{
+		"Default configuration": {
+			content: "extends: default",
+			rules:   map[string]string{"comments": "warning", "document-end": "", "line-length": "error", "truthy": "warning"},
+		},
+		"Relaxed configuration": {
+			content: "extends: relaxed",
+			rules:   map[string]string{"comments": "", "document-start": "", "line-length": "warning", "trailing-spaces": "error"},
+		},
+		"Extended configuration from repository": {
+			content: "extends: .yamllint-base",
+			files:   map[string]string{".yamllint-base": "extends: default\nrules:\n  truthy: disable"},
+			rules:   map[string]string{"line-length": "error", "truthy": ""},
+		},
+		"Rules can be overridden": {
+			content: "extends: default\nrules:\n  comments: enable\n  document-end: {level: warning}\n  line-length: disable",
+			rules:   map[string]string{"comments": "error", "document-end": "warning", "line-length": ""},
+		},
+		"Unknown rule": {
+			content: "rules:\n  unknown: enable",
+			error:   "no such rule: \"unknown\"",
+		},
+		"Unknown option": {
+			content: "rules:\n  line-length: {length: 120}",
+			error:   "invalid config: unknown option \"length\" for rule \"line-length\"",
+		},
+		"Invalid option value": {
+			content: "rules:\n  line-length: {max: long}",
+			error:   "invalid config: option \"max\" of \"line-length\" should be in [0]",
+		},
+		"Invalid level": {
+			content: "rules:\n  line-length: {level: fatal}",
+			error:   "invalid config: level should be \"error\" or \"warning\"",
+		},
+		"Missing extended configuration": {
+			content: "extends: .yamllint-base",
+			error:   "invalid config: the extended configuration \".yamllint-base\" could not be found",
+		},
+		"Invalid YAML": {
+			content: "rules: [",
+			error:   "invalid config: yaml: line 1: did not find expected node content",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := ParseConfig(test.content, repofs.CreateFromMap(test.files))
+
+			if test.error != "" {
+				assert.EqualError(t, err, test.error)
+			} else {
+				assert.NoError(t, err)
+
+				for id, level := range test.rules {
+					assert.Equal(t, level, actual.rules[id].level, "%s expected level %v for rule %s, got %v", name, level, id, actual.rules[id].level)
+				}
+			}
+		})
+	}
+}
+
+func TestIsYamlFile(t *testing.T) {
+	tests := map[string]struct {
+		config   string
+		expected bool
+		path     string
+	}{
+		"YAML file":           {config: "extends: default", expected: true, path: ".gitlab-ci.yml"},
+		"YAML file in folder": {config: "extends: default", expected: true, path: ".github/workflows/ci.yaml"},
+		"Config file":         {config: "extends: default", expected: true, path: ".yamllint"},
+		"Other file":          {config: "extends: default", expected: false, path: "README.md"},
+		"Ignored file":        {config: "extends: default\nignore: '*.yml'", expected: false, path: "action.yml"},
+		"Ignored folder":      {config: "extends: default\nignore: |\n  vendor/\n", expected: false, path: "vendor/lib/file.yml"},
+		"Anchored pattern":    {config: "extends: default\nignore: [/action.yml]", expected: true, path: "examples/action.yml"},
+		"Negated pattern":     {config: "extends: default\nignore: ['*.yml', '!action.yml']", expected: true, path: "action.yml"},
+		"Custom YAML files":   {config: "yaml-files: ['*.yaml']", expected: false, path: "action.yml"},
+		"Ignore from file":    {config: "extends: default\nignore-from-file: .yamlignore", expected: false, path: "action.yml"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config, err := ParseConfig(test.config, repofs.CreateFromMap(map[string]string{".yamlignore": "action.yml\n"}))
+
+			assert.NoError(t, err)
+
+			actual := config.IsYamlFile(test.path)
+
+			assert.Equal(t, test.expected, actual, "%s expected %v, got %v", name, test.expected, actual)
+		})
+	}
+}
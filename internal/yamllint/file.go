@@ -0,0 +1,172 @@
+package yamllint
+
+import (
+	"errors"
+	"gopkg.in/yaml.v3"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	blockScalarPattern = regexp.MustCompile(`(?:^|[\s:?-])[|>][1-9+-]*\s*$`)
+	errorLinePattern   = regexp.MustCompile(`^yaml: line (\d+): `)
+)
+
+// line holds a single line of a YAML file. In `code` the content of quoted
+// strings is masked and comments are removed, so punctuation in it can safely
+// be inspected. Line numbers and columns are 1-based.
+type line struct {
+	code    string
+	comment int
+	content string
+	indent  int
+	number  int
+	scalar  bool
+}
+
+// file holds the source of a YAML file, split into lines, together with the
+// documents parsed from it.
+type file struct {
+	documents   []*yaml.Node
+	lines       []line
+	source      string
+	syntaxError *Problem
+}
+
+func isBlank(text string) bool {
+	return strings.TrimSpace(text) == ""
+}
+
+func getIndent(text string) int {
+	return len(text) - len(strings.TrimLeft(text, " "))
+}
+
+func createFile(source []byte) *file {
+	f := &file{source: string(source)}
+
+	content := strings.ReplaceAll(f.source, "\r\n", "\n")
+	lines := strings.Split(content, "\n")
+
+	// A trailing newline does not start another line
+	if len(lines) > 1 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	f.scanLines(lines)
+	f.parseDocuments()
+
+	return f
+}
+
+func (f *file) parseDocuments() {
+	decoder := yaml.NewDecoder(strings.NewReader(f.source))
+
+	for {
+		var document yaml.Node
+
+		err := decoder.Decode(&document)
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			problem := Problem{Column: 1, Line: 1, Message: "syntax error: " + strings.TrimPrefix(err.Error(), "yaml: ")}
+
+			if matches := errorLinePattern.FindStringSubmatch(err.Error()); matches != nil {
+				problem.Line, _ = strconv.Atoi(matches[1])
+				problem.Message = "syntax error: " + strings.TrimPrefix(err.Error(), matches[0])
+			}
+
+			f.syntaxError = &problem
+			f.documents = nil
+			break
+		}
+
+		f.documents = append(f.documents, &document)
+	}
+}
+
+// scanLines splits the lines into code and comments, keeping track of quoted
+// strings and block scalars that span multiple lines.
+func (f *file) scanLines(lines []string) {
+	var quote rune
+
+	scalarIndent := -1
+
+	for index, content := range lines {
+		current := line{comment: -1, content: content, indent: getIndent(content), number: index + 1}
+
+		if scalarIndent >= 0 {
+			if isBlank(content) || current.indent > scalarIndent {
+				current.scalar = true
+				f.lines = append(f.lines, current)
+				continue
+			}
+
+			scalarIndent = -1
+		}
+
+		current.scalar = quote != 0
+
+		code := []rune(content)
+		previous := ' '
+
+		for position := 0; position < len(code); position++ {
+			character := code[position]
+
+			if quote != 0 {
+				switch {
+				case quote == '"' && character == '\\' && position+1 < len(code):
+					code[position] = '_'
+					position++
+					code[position] = '_'
+				case quote == '\'' && character == '\'' && position+1 < len(code) && code[position+1] == '\'':
+					code[position] = '_'
+					position++
+					code[position] = '_'
+				case character == quote:
+					quote = 0
+				default:
+					code[position] = '_'
+				}
+			} else if character == '#' && (previous == ' ' || previous == '\t') {
+				current.comment = position + 1
+				code = code[:position]
+				break
+			} else if (character == '\'' || character == '"') && strings.ContainsRune(" \t[{,:-?", previous) {
+				quote = character
+			}
+
+			previous = code[position]
+		}
+
+		current.code = strings.TrimRight(string(code), " \t")
+
+		if quote == 0 && blockScalarPattern.MatchString(current.code) {
+			scalarIndent = current.indent
+		}
+
+		f.lines = append(f.lines, current)
+	}
+}
+
+// walk calls the given function for every node in the documents, in the order
+// in which they appear, together with the node's parent.
+func (f *file) walk(callback func(node *yaml.Node, parent *yaml.Node)) {
+	var visit func(node *yaml.Node, parent *yaml.Node)
+
+	visit = func(node *yaml.Node, parent *yaml.Node) {
+		callback(node, parent)
+
+		for _, child := range node.Content {
+			visit(child, node)
+		}
+	}
+
+	for _, document := range f.documents {
+		visit(document, nil)
+	}
+}
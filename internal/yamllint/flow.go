@@ -0,0 +1,133 @@
+package yamllint
+
+import (
+	"strings"
+)
+
+// indicator is a flow indicator (`[`, `]`, `{`, `}` or `,`) or a mapping
+// value indicator (`:`) found in the code of a line.
+type indicator struct {
+	character rune
+	code      []rune
+	line      int
+	position  int
+}
+
+// spacesBefore returns the number of spaces directly before the indicator, or
+// -1 when the indicator is the first character of the line.
+func (i indicator) spacesBefore() int {
+	count := 0
+
+	for position := i.position - 1; position >= 0 && i.code[position] == ' '; position-- {
+		count++
+	}
+
+	if count == i.position {
+		count = -1
+	}
+
+	return count
+}
+
+// spacesAfter returns the number of spaces directly after the indicator, or -1
+// when nothing else follows on the line.
+func (i indicator) spacesAfter() int {
+	count := 0
+
+	for position := i.position + 1; position < len(i.code) && i.code[position] == ' '; position++ {
+		count++
+	}
+
+	if i.position+1+count >= len(i.code) {
+		count = -1
+	}
+
+	return count
+}
+
+// next returns the first character after the indicator that is not a space,
+// or zero when nothing else follows on the line.
+func (i indicator) next() rune {
+	count := i.spacesAfter()
+
+	if count < 0 {
+		return 0
+	}
+
+	return i.code[i.position+1+count]
+}
+
+// indicators finds the flow and mapping value indicators in the file. Flow
+// indicators are only recognised at the start of a node or inside a flow
+// collection, so a plain scalar like `${VAR}` is not mistaken for one.
+func (f *file) indicators() []indicator {
+	var indicators []indicator
+
+	depth := 0
+
+	for _, current := range f.lines {
+		if current.scalar {
+			continue
+		}
+
+		code := []rune(current.code)
+		nodeStart := true
+
+		for position := 0; position < len(code); position++ {
+			character := code[position]
+			following := ' '
+
+			if position+1 < len(code) {
+				following = code[position+1]
+			}
+
+			isIndicator := false
+
+			switch {
+			case character == ' ' || character == '\t':
+				continue
+
+			case strings.ContainsRune("[{", character) && (nodeStart || depth > 0):
+				depth++
+				isIndicator = true
+				nodeStart = true
+
+			case strings.ContainsRune("]}", character) && depth > 0:
+				depth--
+				isIndicator = true
+				nodeStart = false
+
+			case character == ',' && depth > 0:
+				isIndicator = true
+				nodeStart = true
+
+			case character == ':' && (following == ' ' || (depth > 0 && strings.ContainsRune(",]}", following))):
+				isIndicator = true
+				nodeStart = true
+
+			case strings.ContainsRune("-?", character) && nodeStart && following == ' ':
+				// A block sequence entry or complex key, the node starts after it
+
+			case strings.ContainsRune("&!", character) && nodeStart:
+				// Anchors and tags are followed by the node they belong to
+				for position+1 < len(code) && code[position+1] != ' ' {
+					position++
+				}
+
+			default:
+				nodeStart = false
+			}
+
+			if isIndicator {
+				indicators = append(indicators, indicator{
+					character: character,
+					code:      code,
+					line:      current.number,
+					position:  position,
+				})
+			}
+		}
+	}
+
+	return indicators
+}
@@ -0,0 +1,939 @@
+package yamllint
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	explicitOctalPattern = regexp.MustCompile(`^0o[0-7]+$`)
+	floatPattern         = regexp.MustCompile(`^[-+]?(?:\.[0-9]+|[0-9]+(?:\.[0-9]*)?)(?:[eE][-+]?[0-9]+)?$`)
+	implicitOctalPattern = regexp.MustCompile(`^0[0-7]+$`)
+	infinityPattern      = regexp.MustCompile(`^[-+]?\.(?:inf|Inf|INF)$`)
+	missingNumeral       = regexp.MustCompile(`^[-+]?\.[0-9]`)
+	notANumberPattern    = regexp.MustCompile(`^\.(?:nan|NaN|NAN)$`)
+	truthyValues         = []string{"YES", "Yes", "yes", "NO", "No", "no", "TRUE", "True", "true", "FALSE", "False", "false", "ON", "On", "on", "OFF", "Off", "off"}
+)
+
+func problem(line int, column int, format string, arguments ...interface{}) Problem {
+	return Problem{Column: column, Line: line, Message: fmt.Sprintf(format, arguments...)}
+}
+
+func isKey(node *yaml.Node, parent *yaml.Node) bool {
+	isKey := false
+
+	if parent != nil && parent.Kind == yaml.MappingNode {
+		index := slices.Index(parent.Content, node)
+		isKey = index >= 0 && index%2 == 0
+	}
+
+	return isKey
+}
+
+func isPlainScalar(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Style == 0
+}
+
+func checkFlowSpacing(f *file, o options, opening rune, closing rune, name string) []Problem {
+	var problems []Problem
+
+	forbidden := map[rune]string{'[': "flow sequence", '{': "flow mapping"}[opening]
+	emptyMinimum := o.int("min-spaces-inside-empty")
+	emptyMaximum := o.int("max-spaces-inside-empty")
+
+	if emptyMinimum < 0 {
+		emptyMinimum = o.int("min-spaces-inside")
+	}
+
+	if emptyMaximum < 0 {
+		emptyMaximum = o.int("max-spaces-inside")
+	}
+
+	for _, indicator := range f.indicators() {
+		if indicator.character == opening {
+			isEmpty := indicator.next() == closing
+			spaces := indicator.spacesAfter()
+			next := indicator.position + 1 + spaces
+
+			if o["forbid"] == true || (o["forbid"] == "non-empty" && !isEmpty) {
+				problems = append(problems, problem(indicator.line, indicator.position+1, "forbidden %s", forbidden))
+			} else if isEmpty {
+				if emptyMaximum >= 0 && spaces > emptyMaximum {
+					problems = append(problems, problem(indicator.line, next, "too many spaces inside empty %s", name))
+				} else if spaces < emptyMinimum {
+					problems = append(problems, problem(indicator.line, next+1, "too few spaces inside empty %s", name))
+				}
+			} else if spaces >= 0 {
+				if o.int("max-spaces-inside") >= 0 && spaces > o.int("max-spaces-inside") {
+					problems = append(problems, problem(indicator.line, next, "too many spaces inside %s", name))
+				} else if spaces < o.int("min-spaces-inside") {
+					problems = append(problems, problem(indicator.line, next+1, "too few spaces inside %s", name))
+				}
+			}
+		} else if indicator.character == closing {
+			spaces := indicator.spacesBefore()
+			isEmpty := spaces >= 0 && indicator.position-spaces > 0 && indicator.code[indicator.position-spaces-1] == opening
+
+			if spaces >= 0 && !isEmpty {
+				if o.int("max-spaces-inside") >= 0 && spaces > o.int("max-spaces-inside") {
+					problems = append(problems, problem(indicator.line, indicator.position, "too many spaces inside %s", name))
+				} else if spaces < o.int("min-spaces-inside") {
+					problems = append(problems, problem(indicator.line, indicator.position+1, "too few spaces inside %s", name))
+				}
+			}
+		}
+	}
+
+	return problems
+}
+
+var rules = []rule{
+	{
+		id: "anchors",
+		defaults: options{
+			"forbid-duplicated-anchors": false,
+			"forbid-undeclared-aliases": true,
+			"forbid-unused-anchors":     false,
+		},
+		types: map[string][]interface{}{
+			"forbid-duplicated-anchors": {false},
+			"forbid-undeclared-aliases": {false},
+			"forbid-unused-anchors":     {false},
+		},
+		check: func(f *file, o options) []Problem {
+			var problems []Problem
+
+			for _, document := range f.documents {
+				anchors := map[string]*yaml.Node{}
+				used := map[string]bool{}
+
+				var visit func(node *yaml.Node)
+
+				visit = func(node *yaml.Node) {
+					if node.Kind == yaml.AliasNode {
+						if _, ok := anchors[node.Value]; !ok && o.bool("forbid-undeclared-aliases") {
+							problems = append(problems, problem(node.Line, node.Column, "found undeclared alias \"%s\"", node.Value))
+						}
+
+						used[node.Value] = true
+					} else if node.Anchor != "" {
+						if _, ok := anchors[node.Anchor]; ok && o.bool("forbid-duplicated-anchors") {
+							problems = append(problems, problem(node.Line, node.Column, "found duplicated anchor \"%s\"", node.Anchor))
+						}
+
+						anchors[node.Anchor] = node
+						used[node.Anchor] = false
+					}
+
+					for _, child := range node.Content {
+						visit(child)
+					}
+				}
+
+				visit(document)
+
+				if o.bool("forbid-unused-anchors") {
+					for name, node := range anchors {
+						if !used[name] {
+							problems = append(problems, problem(node.Line, node.Column, "found unused anchor \"%s\"", name))
+						}
+					}
+				}
+			}
+
+			return problems
+		},
+	},
+	{
+		id: "braces",
+		defaults: options{
+			"forbid":                  false,
+			"max-spaces-inside":       0,
+			"max-spaces-inside-empty": -1,
+			"min-spaces-inside":       0,
+			"min-spaces-inside-empty": -1,
+		},
+		types: map[string][]interface{}{
+			"forbid":                  {false, "non-empty"},
+			"max-spaces-inside":       {0},
+			"max-spaces-inside-empty": {0},
+			"min-spaces-inside":       {0},
+			"min-spaces-inside-empty": {0},
+		},
+		check: func(f *file, o options) []Problem {
+			return checkFlowSpacing(f, o, '{', '}', "braces")
+		},
+	},
+	{
+		id: "brackets",
+		defaults: options{
+			"forbid":                  false,
+			"max-spaces-inside":       0,
+			"max-spaces-inside-empty": -1,
+			"min-spaces-inside":       0,
+			"min-spaces-inside-empty": -1,
+		},
+		types: map[string][]interface{}{
+			"forbid":                  {false, "non-empty"},
+			"max-spaces-inside":       {0},
+			"max-spaces-inside-empty": {0},
+			"min-spaces-inside":       {0},
+			"min-spaces-inside-empty": {0},
+		},
+		check: func(f *file, o options) []Problem {
+			return checkFlowSpacing(f, o, '[', ']', "brackets")
+		},
+	},
+	{
+		id:       "colons",
+		defaults: options{"max-spaces-after": 1, "max-spaces-before": 0},
+		types:    map[string][]interface{}{"max-spaces-after": {0}, "max-spaces-before": {0}},
+		check: func(f *file, o options) []Problem {
+			var problems []Problem
+
+			for _, indicator := range f.indicators() {
+				if indicator.character != ':' {
+					continue
+				}
+
+				before := indicator.spacesBefore()
+				after := indicator.spacesAfter()
+
+				if o.int("max-spaces-before") >= 0 && before > o.int("max-spaces-before") {
+					problems = append(problems, problem(indicator.line, indicator.position, "too many spaces before colon"))
+				}
+
+				if o.int("max-spaces-after") >= 0 && after > o.int("max-spaces-after") {
+					problems = append(problems, problem(indicator.line, indicator.position+1+after, "too many spaces after colon"))
+				}
+			}
+
+			return problems
+		},
+	},
+	{
+		id:       "commas",
+		defaults: options{"max-spaces-after": 1, "max-spaces-before": 0, "min-spaces-after": 1},
+		types:    map[string][]interface{}{"max-spaces-after": {0}, "max-spaces-before": {0}, "min-spaces-after": {0}},
+		check: func(f *file, o options) []Problem {
+			var problems []Problem
+
+			for _, indicator := range f.indicators() {
+				if indicator.character != ',' {
+					continue
+				}
+
+				before := indicator.spacesBefore()
+				after := indicator.spacesAfter()
+
+				if o.int("max-spaces-before") >= 0 && before > o.int("max-spaces-before") {
+					problems = append(problems, problem(indicator.line, indicator.position, "too many spaces before comma"))
+				}
+
+				if after >= 0 {
+					if o.int("max-spaces-after") >= 0 && after > o.int("max-spaces-after") {
+						problems = append(problems, problem(indicator.line, indicator.position+1+after, "too many spaces after comma"))
+					} else if after < o.int("min-spaces-after") {
+						problems = append(problems, problem(indicator.line, indicator.position+2, "too few spaces after comma"))
+					}
+				}
+			}
+
+			return problems
+		},
+	},
+	{
+		id: "comments",
+		defaults: options{
+			"ignore-shebangs":         true,
+			"min-spaces-from-content": 2,
+			"require-starting-space":  true,
+		},
+		types: map[string][]interface{}{
+			"ignore-shebangs":         {false},
+			"min-spaces-from-content": {0},
+			"require-starting-space":  {false},
+		},
+		check: func(f *file, o options) []Problem {
+			var problems []Problem
+
+			for _, current := range f.lines {
+				if current.scalar || current.comment < 0 {
+					continue
+				}
+
+				content := []rune(current.content)
+				text := strings.TrimLeft(string(content[current.comment-1:]), "#")
+				hashes := utf8.RuneCountInString(string(content[current.comment-1:])) - utf8.RuneCountInString(text)
+				isShebang := current.number == 1 && current.comment == 1 && strings.HasPrefix(text, "!")
+
+				if o.bool("require-starting-space") && text != "" && !strings.HasPrefix(text, " ") && !(isShebang && o.bool("ignore-shebangs")) {
+					problems = append(problems, problem(current.number, current.comment+hashes, "missing starting space in comment"))
+				}
+
+				if !isBlank(current.code) {
+					before := string(content[:current.comment-1])
+					spaces := len(before) - len(strings.TrimRight(before, " \t"))
+
+					if spaces < o.int("min-spaces-from-content") {
+						problems = append(problems, problem(current.number, current.comment, "too few spaces before comment"))
+					}
+				}
+			}
+
+			return problems
+		},
+	},
+	{
+		id: "comments-indentation",
+		check: func(f *file, _ options) []Problem {
+			var problems []Problem
+
+			isContent := func(current line) bool {
+				return !current.scalar && !isBlank(current.code)
+			}
+
+			previousIndent := 0
+			previousComment := -1
+
+			for index, current := range f.lines {
+				if isContent(current) {
+					previousIndent = current.indent
+					previousComment = -1
+					continue
+				}
+
+				if current.scalar || current.comment < 0 {
+					continue
+				}
+
+				nextIndent := 0
+
+				for _, next := range f.lines[index+1:] {
+					if isContent(next) {
+						nextIndent = next.indent
+						break
+					}
+				}
+
+				validIndent := previousIndent
+
+				if validIndent <= nextIndent {
+					validIndent = nextIndent
+				}
+
+				if previousComment >= 0 {
+					validIndent = previousComment
+				}
+
+				if current.indent != validIndent && current.indent != nextIndent {
+					problems = append(problems, problem(current.number, current.indent+1, "comment not indented like content"))
+				}
+
+				previousComment = current.indent
+			}
+
+			return problems
+		},
+	},
+	{
+		id:       "document-end",
+		defaults: options{"present": true},
+		types:    map[string][]interface{}{"present": {false}},
+		check: func(f *file, o options) []Problem {
+			var problems []Problem
+
+			last := -1
+
+			for index, current := range f.lines {
+				if current.scalar {
+					continue
+				}
+
+				if current.code == "..." && !o.bool("present") {
+					problems = append(problems, problem(current.number, 1, "found forbidden document end \"...\""))
+				}
+
+				if !isBlank(current.code) {
+					last = index
+				}
+			}
+
+			if o.bool("present") && last >= 0 && f.lines[last].code != "..." {
+				problems = append(problems, problem(f.lines[last].number+1, 1, "missing document end \"...\""))
+			}
+
+			return problems
+		},
+	},
+	{
+		id:       "document-start",
+		defaults: options{"present": true},
+		types:    map[string][]interface{}{"present": {false}},
+		check: func(f *file, o options) []Problem {
+			var problems []Problem
+
+			isStart := func(code string) bool {
+				return code == "---" || strings.HasPrefix(code, "--- ")
+			}
+
+			for index, current := range f.lines {
+				if current.scalar || isBlank(current.code) || strings.HasPrefix(current.code, "%") {
+					continue
+				}
+
+				if o.bool("present") {
+					if index == slices.IndexFunc(f.lines, func(candidate line) bool {
+						return !isBlank(candidate.code) && !strings.HasPrefix(candidate.code, "%")
+					}) && !isStart(current.code) {
+						problems = append(problems, problem(current.number, 1, "missing document start \"---\""))
+					}
+				} else if isStart(current.code) {
+					problems = append(problems, problem(current.number, 1, "found forbidden document start \"---\""))
+				}
+			}
+
+			return problems
+		},
+	},
+	{
+		id:       "empty-lines",
+		defaults: options{"max": 2, "max-end": 0, "max-start": 0},
+		types:    map[string][]interface{}{"max": {0}, "max-end": {0}, "max-start": {0}},
+		check: func(f *file, o options) []Problem {
+			var problems []Problem
+
+			if f.source == "" {
+				return problems
+			}
+
+			count := 0
+
+			for index, current := range f.lines {
+				if current.content != "" {
+					count = 0
+					continue
+				}
+
+				count++
+
+				isLast := index+1 == len(f.lines) || f.lines[index+1].content != ""
+				maximum := o.int("max")
+
+				if count == index+1 {
+					maximum = o.int("max-start")
+				} else if index+1 == len(f.lines) {
+					maximum = o.int("max-end")
+				}
+
+				if isLast && count > maximum {
+					problems = append(problems, problem(current.number, 1, "too many blank lines (%d > %d)", count, maximum))
+				}
+			}
+
+			return problems
+		},
+	},
+	{
+		id: "empty-values",
+		defaults: options{
+			"forbid-in-block-mappings":  true,
+			"forbid-in-block-sequences": true,
+			"forbid-in-flow-mappings":   true,
+		},
+		types: map[string][]interface{}{
+			"forbid-in-block-mappings":  {false},
+			"forbid-in-block-sequences": {false},
+			"forbid-in-flow-mappings":   {false},
+		},
+		check: func(f *file, o options) []Problem {
+			var problems []Problem
+
+			isEmpty := func(node *yaml.Node) bool {
+				return isPlainScalar(node) && node.Tag == "!!null" && node.Value == ""
+			}
+
+			f.walk(func(node *yaml.Node, parent *yaml.Node) {
+				if parent == nil || !isEmpty(node) || isKey(node, parent) {
+					return
+				}
+
+				isFlow := parent.Style&yaml.FlowStyle != 0
+
+				switch {
+				case parent.Kind == yaml.MappingNode && isFlow && o.bool("forbid-in-flow-mappings"):
+					problems = append(problems, problem(node.Line, node.Column, "empty value in flow mapping"))
+				case parent.Kind == yaml.MappingNode && !isFlow && o.bool("forbid-in-block-mappings"):
+					problems = append(problems, problem(node.Line, node.Column, "empty value in block mapping"))
+				case parent.Kind == yaml.SequenceNode && !isFlow && o.bool("forbid-in-block-sequences"):
+					problems = append(problems, problem(node.Line, node.Column, "empty value in block sequence"))
+				}
+			})
+
+			return problems
+		},
+	},
+	{
+		id: "float-values",
+		defaults: options{
+			"forbid-inf":                     false,
+			"forbid-nan":                     false,
+			"forbid-scientific-notation":     false,
+			"require-numeral-before-decimal": false,
+		},
+		types: map[string][]interface{}{
+			"forbid-inf":                     {false},
+			"forbid-nan":                     {false},
+			"forbid-scientific-notation":     {false},
+			"require-numeral-before-decimal": {false},
+		},
+		check: func(f *file, o options) []Problem {
+			var problems []Problem
+
+			f.walk(func(node *yaml.Node, _ *yaml.Node) {
+				if !isPlainScalar(node) {
+					return
+				}
+
+				value := node.Value
+
+				switch {
+				case o.bool("forbid-nan") && notANumberPattern.MatchString(value):
+					problems = append(problems, problem(node.Line, node.Column, "forbidden not a number value \"%s\"", value))
+				case o.bool("forbid-inf") && infinityPattern.MatchString(value):
+					problems = append(problems, problem(node.Line, node.Column, "forbidden infinite value \"%s\"", value))
+				case o.bool("forbid-scientific-notation") && floatPattern.MatchString(value) && strings.ContainsAny(value, "eE"):
+					problems = append(problems, problem(node.Line, node.Column, "forbidden scientific notation \"%s\"", value))
+				case o.bool("require-numeral-before-decimal") && floatPattern.MatchString(value) && missingNumeral.MatchString(value):
+					problems = append(problems, problem(node.Line, node.Column, "forbidden decimal missing 0 prefix \"%s\"", value))
+				}
+			})
+
+			return problems
+		},
+	},
+	{
+		id:       "hyphens",
+		defaults: options{"max-spaces-after": 1},
+		types:    map[string][]interface{}{"max-spaces-after": {0}},
+		check: func(f *file, o options) []Problem {
+			var problems []Problem
+
+			for _, current := range f.lines {
+				if current.scalar {
+					continue
+				}
+
+				code := []rune(current.code)
+				position := current.indent
+
+				for position+1 < len(code) && code[position] == '-' && code[position+1] == ' ' {
+					spaces := 0
+
+					for position+1+spaces < len(code) && code[position+1+spaces] == ' ' {
+						spaces++
+					}
+
+					if position+1+spaces < len(code) && spaces > o.int("max-spaces-after") {
+						problems = append(problems, problem(current.number, position+1+spaces, "too many spaces after hyphen"))
+					}
+
+					position += 1 + spaces
+				}
+			}
+
+			return problems
+		},
+	},
+	{
+		id: "indentation",
+		defaults: options{
+			"check-multi-line-strings": false,
+			"indent-sequences":         true,
+			"spaces":                   "consistent",
+		},
+		types: map[string][]interface{}{
+			"check-multi-line-strings": {false},
+			"indent-sequences":         {false, "whatever", "consistent"},
+			"spaces":                   {0, "consistent"},
+		},
+		check: func(f *file, o options) []Problem {
+			var problems []Problem
+
+			spaces := -1
+			indentSequences := o["indent-sequences"]
+
+			if value, ok := o["spaces"].(int); ok {
+				spaces = value
+			}
+
+			report := func(node *yaml.Node, expected int) {
+				if node.Column-1 != expected {
+					problems = append(problems, problem(node.Line, node.Column, "wrong indentation: expected %d but found %d", expected, node.Column-1))
+				}
+			}
+
+			f.walk(func(node *yaml.Node, parent *yaml.Node) {
+				if node.Kind == yaml.DocumentNode && len(node.Content) > 0 && node.Content[0].Style&yaml.FlowStyle == 0 {
+					if content := node.Content[0]; content.Kind == yaml.MappingNode || content.Kind == yaml.SequenceNode {
+						report(content, 0)
+					}
+				}
+
+				if node.Kind != yaml.MappingNode || node.Style&yaml.FlowStyle != 0 {
+					return
+				}
+
+				for index := 0; index+1 < len(node.Content); index += 2 {
+					key := node.Content[index]
+					value := node.Content[index+1]
+
+					if value.Line <= key.Line || value.Style&yaml.FlowStyle != 0 {
+						continue
+					}
+
+					if value.Kind == yaml.MappingNode {
+						if spaces < 0 {
+							spaces = value.Column - key.Column
+						}
+
+						report(value, key.Column-1+spaces)
+					} else if value.Kind == yaml.SequenceNode {
+						isIndented := value.Column > key.Column
+
+						if spaces < 0 && isIndented {
+							spaces = value.Column - key.Column
+						}
+
+						if indentSequences == "consistent" {
+							indentSequences = isIndented
+						}
+
+						switch {
+						case indentSequences == true:
+							report(value, key.Column-1+max(spaces, 0))
+						case indentSequences == false:
+							report(value, key.Column-1)
+						case isIndented && spaces >= 0:
+							report(value, key.Column-1+spaces)
+						}
+					}
+				}
+			})
+
+			return problems
+		},
+	},
+	{
+		id:       "key-duplicates",
+		defaults: options{"forbid-duplicated-merge-keys": false},
+		types:    map[string][]interface{}{"forbid-duplicated-merge-keys": {false}},
+		check: func(f *file, o options) []Problem {
+			var problems []Problem
+
+			f.walk(func(node *yaml.Node, _ *yaml.Node) {
+				if node.Kind != yaml.MappingNode {
+					return
+				}
+
+				keys := map[string]bool{}
+
+				for index := 0; index < len(node.Content); index += 2 {
+					key := node.Content[index]
+
+					if key.Kind != yaml.ScalarNode {
+						continue
+					}
+
+					if keys[key.Value] && (key.Value != "<<" || o.bool("forbid-duplicated-merge-keys")) {
+						problems = append(problems, problem(key.Line, key.Column, "duplication of key \"%s\" in mapping", key.Value))
+					}
+
+					keys[key.Value] = true
+				}
+			})
+
+			return problems
+		},
+	},
+	{
+		id:       "key-ordering",
+		defaults: options{"ignored-keys": []interface{}{}},
+		types:    map[string][]interface{}{"ignored-keys": {[]interface{}{}}},
+		check: func(f *file, o options) []Problem {
+			var problems []Problem
+
+			var ignored []*regexp.Regexp
+
+			for _, pattern := range o.strings("ignored-keys") {
+				if expression, err := regexp.Compile(pattern); err == nil {
+					ignored = append(ignored, expression)
+				}
+			}
+
+			f.walk(func(node *yaml.Node, _ *yaml.Node) {
+				if node.Kind != yaml.MappingNode {
+					return
+				}
+
+				previous := ""
+
+				for index := 0; index < len(node.Content); index += 2 {
+					key := node.Content[index]
+
+					isIgnored := slices.ContainsFunc(ignored, func(expression *regexp.Regexp) bool {
+						return expression.MatchString(key.Value)
+					})
+
+					if key.Kind != yaml.ScalarNode || isIgnored {
+						continue
+					}
+
+					if key.Value < previous {
+						problems = append(problems, problem(key.Line, key.Column, "wrong ordering of key \"%s\" in mapping", key.Value))
+					}
+
+					previous = key.Value
+				}
+			})
+
+			return problems
+		},
+	},
+	{
+		id: "line-length",
+		defaults: options{
+			"allow-non-breakable-inline-mappings": false,
+			"allow-non-breakable-words":           true,
+			"max":                                 80,
+		},
+		types: map[string][]interface{}{
+			"allow-non-breakable-inline-mappings": {false},
+			"allow-non-breakable-words":           {false},
+			"max":                                 {0},
+		},
+		check: func(f *file, o options) []Problem {
+			var problems []Problem
+
+			for _, current := range f.lines {
+				length := utf8.RuneCountInString(current.content)
+
+				if length <= o.int("max") {
+					continue
+				}
+
+				if o.bool("allow-non-breakable-words") || o.bool("allow-non-breakable-inline-mappings") {
+					text := strings.TrimLeft(current.content, " ")
+
+					if strings.HasPrefix(text, "#") {
+						text = strings.TrimLeft(text, "#")
+						text = strings.TrimPrefix(text, " ")
+					} else if strings.HasPrefix(text, "- ") {
+						text = text[2:]
+					}
+
+					if !strings.Contains(text, " ") {
+						continue
+					}
+
+					_, value, found := strings.Cut(current.code, ": ")
+					value = strings.TrimSpace(value)
+
+					if o.bool("allow-non-breakable-inline-mappings") && found && !current.scalar && !strings.ContainsAny(value, " \t") {
+						continue
+					}
+				}
+
+				problems = append(problems, problem(current.number, o.int("max")+1, "line too long (%d > %d characters)", length, o.int("max")))
+			}
+
+			return problems
+		},
+	},
+	{
+		id: "new-line-at-end-of-file",
+		check: func(f *file, _ options) []Problem {
+			var problems []Problem
+
+			if f.source != "" && !strings.HasSuffix(f.source, "\n") {
+				last := f.lines[len(f.lines)-1]
+				problems = append(problems, problem(last.number, utf8.RuneCountInString(last.content)+1, "no new line character at the end of file"))
+			}
+
+			return problems
+		},
+	},
+	{
+		id:       "new-lines",
+		defaults: options{"type": "unix"},
+		types:    map[string][]interface{}{"type": {"unix", "dos", "platform"}},
+		check: func(f *file, o options) []Problem {
+			var problems []Problem
+
+			end := strings.Index(f.source, "\n")
+
+			if end < 0 {
+				return problems
+			}
+
+			isDos := end > 0 && f.source[end-1] == '\r'
+			column := utf8.RuneCountInString(strings.TrimSuffix(f.source[:end], "\r")) + 1
+
+			if o.string("type") == "dos" && !isDos {
+				problems = append(problems, problem(1, column, "wrong new line character: expected \\r\\n"))
+			} else if o.string("type") != "dos" && isDos {
+				problems = append(problems, problem(1, column, "wrong new line character: expected \\n"))
+			}
+
+			return problems
+		},
+	},
+	{
+		id:       "octal-values",
+		defaults: options{"forbid-explicit-octal": true, "forbid-implicit-octal": true},
+		types:    map[string][]interface{}{"forbid-explicit-octal": {false}, "forbid-implicit-octal": {false}},
+		check: func(f *file, o options) []Problem {
+			var problems []Problem
+
+			f.walk(func(node *yaml.Node, _ *yaml.Node) {
+				if !isPlainScalar(node) {
+					return
+				}
+
+				if o.bool("forbid-implicit-octal") && implicitOctalPattern.MatchString(node.Value) {
+					problems = append(problems, problem(node.Line, node.Column, "forbidden implicit octal value \"%s\"", node.Value))
+				} else if o.bool("forbid-explicit-octal") && explicitOctalPattern.MatchString(node.Value) {
+					problems = append(problems, problem(node.Line, node.Column, "forbidden explicit octal value \"%s\"", node.Value))
+				}
+			})
+
+			return problems
+		},
+	},
+	{
+		id: "quoted-strings",
+		defaults: options{
+			"allow-quoted-quotes": false,
+			"check-keys":          false,
+			"extra-allowed":       []interface{}{},
+			"extra-required":      []interface{}{},
+			"quote-type":          "any",
+			"required":            true,
+		},
+		types: map[string][]interface{}{
+			"allow-quoted-quotes": {false},
+			"check-keys":          {false},
+			"extra-allowed":       {[]interface{}{}},
+			"extra-required":      {[]interface{}{}},
+			"quote-type":          {"any", "single", "double"},
+			"required":            {false, "only-when-needed"},
+		},
+		check: func(f *file, o options) []Problem {
+			var problems []Problem
+
+			matchesAny := func(value string, patterns []string) bool {
+				return slices.ContainsFunc(patterns, func(pattern string) bool {
+					expression, err := regexp.Compile(pattern)
+
+					return err == nil && expression.MatchString(value)
+				})
+			}
+
+			isQuoteNeeded := func(value string) bool {
+				var document yaml.Node
+
+				if value == "" || strings.TrimSpace(value) != value || strings.ContainsAny(value, "\n#:") {
+					return true
+				}
+
+				if err := yaml.Unmarshal([]byte(value), &document); err != nil || len(document.Content) != 1 {
+					return true
+				}
+
+				node := document.Content[0]
+
+				return !isPlainScalar(node) || node.Tag != "!!str" || node.Value != value
+			}
+
+			quoteType := o.string("quote-type")
+			styles := map[string]yaml.Style{"double": yaml.DoubleQuotedStyle, "single": yaml.SingleQuotedStyle}
+
+			f.walk(func(node *yaml.Node, parent *yaml.Node) {
+				if node.Kind != yaml.ScalarNode || node.Tag != "!!str" || (isKey(node, parent) && !o.bool("check-keys")) {
+					return
+				}
+
+				if node.Style&(yaml.LiteralStyle|yaml.FoldedStyle|yaml.TaggedStyle) != 0 {
+					return
+				}
+
+				isQuoted := node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0
+				hasQuoteType := quoteType == "any" || node.Style&styles[quoteType] != 0
+
+				if o.bool("allow-quoted-quotes") && isQuoted && !hasQuoteType {
+					hasQuoteType = strings.ContainsAny(node.Value, "'\"")
+				}
+
+				switch {
+				case !isQuoted && (o["required"] == true || matchesAny(node.Value, o.strings("extra-required"))):
+					problems = append(problems, problem(node.Line, node.Column, "string value is not quoted"))
+				case isQuoted && !hasQuoteType:
+					problems = append(problems, problem(node.Line, node.Column, "string value is not quoted with %s quotes", quoteType))
+				case isQuoted && o["required"] == "only-when-needed" && !isQuoteNeeded(node.Value) && !matchesAny(node.Value, o.strings("extra-allowed")):
+					name := "single"
+
+					if node.Style&yaml.DoubleQuotedStyle != 0 {
+						name = "double"
+					}
+
+					problems = append(problems, problem(node.Line, node.Column, "string value is redundantly quoted with %s quotes", name))
+				}
+			})
+
+			return problems
+		},
+	},
+	{
+		id: "trailing-spaces",
+		check: func(f *file, _ options) []Problem {
+			var problems []Problem
+
+			for _, current := range f.lines {
+				trimmed := strings.TrimRight(current.content, " \t")
+
+				if trimmed != current.content {
+					problems = append(problems, problem(current.number, utf8.RuneCountInString(trimmed)+1, "trailing spaces"))
+				}
+			}
+
+			return problems
+		},
+	},
+	{
+		id:       "truthy",
+		defaults: options{"allowed-values": []interface{}{"true", "false"}, "check-keys": true},
+		types:    map[string][]interface{}{"allowed-values": {[]interface{}{}}, "check-keys": {false}},
+		check: func(f *file, o options) []Problem {
+			var problems []Problem
+
+			allowed := o.strings("allowed-values")
+			slices.Sort(allowed)
+
+			f.walk(func(node *yaml.Node, parent *yaml.Node) {
+				if !isPlainScalar(node) || (isKey(node, parent) && !o.bool("check-keys")) {
+					return
+				}
+
+				if slices.Contains(truthyValues, node.Value) && !slices.Contains(allowed, node.Value) {
+					problems = append(problems, problem(node.Line, node.Column, "truthy value should be one of [%s]", strings.Join(allowed, ", ")))
+				}
+			})
+
+			return problems
+		},
+	},
+}
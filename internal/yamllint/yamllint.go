@@ -0,0 +1,96 @@
+package yamllint
+
+import (
+	"fmt"
+	"path"
+	"sort"
+)
+
+type rule struct {
+	check    func(file *file, options options) []Problem
+	defaults options
+	id       string
+	types    map[string][]interface{}
+}
+
+type options map[string]interface{}
+
+func (o options) bool(name string) bool {
+	value, _ := o[name].(bool)
+
+	return value
+}
+
+func (o options) int(name string) int {
+	value, _ := o[name].(int)
+
+	return value
+}
+
+func (o options) string(name string) string {
+	value, _ := o[name].(string)
+
+	return value
+}
+
+func (o options) strings(name string) []string {
+	var values []string
+
+	list, _ := o[name].([]interface{})
+
+	for _, value := range list {
+		values = append(values, fmt.Sprint(value))
+	}
+
+	return values
+}
+
+// Problem is a single violation of a rule, at a given line and column.
+type Problem struct {
+	Column  int
+	Level   string
+	Line    int
+	Message string
+	Rule    string
+}
+
+// Lint checks the given YAML source against the rules enabled in the given
+// config. Rules that ignore the given path are not applied.
+func Lint(filePath string, source []byte, config Config) []Problem {
+	var problems []Problem
+
+	yamlFile := createFile(source)
+
+	if yamlFile.syntaxError != nil {
+		problem := *yamlFile.syntaxError
+		problem.Level = "error"
+		problem.Rule = "syntax"
+
+		problems = append(problems, problem)
+	}
+
+	for _, rule := range rules {
+		ruleConfig, enabled := config.rules[rule.id]
+
+		if !enabled || matchesPatterns(path.Clean(filePath), ruleConfig.ignore) {
+			continue
+		}
+
+		for _, problem := range rule.check(yamlFile, ruleConfig.options) {
+			problem.Level = ruleConfig.level
+			problem.Rule = rule.id
+
+			problems = append(problems, problem)
+		}
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].Line == problems[j].Line {
+			return problems[i].Column < problems[j].Column
+		}
+
+		return problems[i].Line < problems[j].Line
+	})
+
+	return problems
+}
@@ -0,0 +1,124 @@
+package yamllint
+
+import (
+	"github.com/stretchr/testify/assert"
+	"internal/repofs"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	tests := map[string]struct {
+		config   string
+		expected []Problem
+		source   string
+	}{
+		"Valid file": {
+			config:   "extends: default",
+			expected: nil,
+			source:   "---\n# Comment\nstages:\n  - build\n\nbuild:\n  script:\n    - echo \"a: b\"  # note\n    - |\n      multi line: with colon\n        indented   # not a comment\n  only: [main, \"dev\"]\n  image: ${CI_REGISTRY}/image:${TAG}\n",
+		},
+		"Syntax error": {
+			config:   "extends: default",
+			expected: []Problem{{Column: 1, Level: "error", Line: 3, Message: "syntax error: could not find expected ':'", Rule: "syntax"}},
+			source:   "---\nkey: value\ninvalid\n",
+		},
+		"Rules from the extended configuration": {
+			config: "extends: default",
+			expected: []Problem{
+				{Column: 1, Level: "warning", Line: 1, Message: "missing document start \"---\"", Rule: "document-start"},
+				{Column: 5, Level: "error", Line: 1, Message: "trailing spaces", Rule: "trailing-spaces"},
+				{Column: 1, Level: "warning", Line: 2, Message: "truthy value should be one of [false, true]", Rule: "truthy"},
+				{Column: 5, Level: "warning", Line: 2, Message: "truthy value should be one of [false, true]", Rule: "truthy"},
+				{Column: 8, Level: "error", Line: 2, Message: "no new line character at the end of file", Rule: "new-line-at-end-of-file"},
+			},
+			source: "a: b  \non: yes",
+		},
+		"Rule levels and options can be changed": {
+			config: "extends: default\nrules:\n  document-start: disable\n  line-length:\n    max: 10\n    level: warning\n  truthy: disable",
+			expected: []Problem{
+				{Column: 11, Level: "warning", Line: 1, Message: "line too long (17 > 10 characters)", Rule: "line-length"},
+			},
+			source: "key: a long value\non: yes\n",
+		},
+		"Relaxed configuration": {
+			config: "extends: relaxed",
+			expected: []Problem{
+				{Column: 6, Level: "warning", Line: 1, Message: "too many spaces after colon", Rule: "colons"},
+			},
+			source: "key:  value\n",
+		},
+		"Rules can ignore files": {
+			config:   "extends: default\nrules:\n  document-start:\n    ignore: '*.yml'",
+			expected: nil,
+			source:   "key: value\n",
+		},
+		"Flow collections": {
+			config: "rules:\n  braces: enable\n  brackets: enable\n  commas: enable",
+			expected: []Problem{
+				{Column: 8, Level: "error", Line: 1, Message: "too many spaces inside brackets", Rule: "brackets"},
+				{Column: 11, Level: "error", Line: 1, Message: "too few spaces after comma", Rule: "commas"},
+				{Column: 12, Level: "error", Line: 1, Message: "too many spaces inside brackets", Rule: "brackets"},
+				{Column: 7, Level: "error", Line: 2, Message: "too many spaces inside braces", Rule: "braces"},
+				{Column: 9, Level: "error", Line: 2, Message: "too many spaces before comma", Rule: "commas"},
+			},
+			source: "list: [ a,b ]\nmap: { a , b: c}\nplain: ${VAR}\n",
+		},
+		"Block structure": {
+			config: "rules:\n  colons: enable\n  empty-lines: enable\n  hyphens: enable\n  indentation: {spaces: 2}",
+			expected: []Problem{
+				{Column: 5, Level: "error", Line: 2, Message: "wrong indentation: expected 2 but found 4", Rule: "indentation"},
+				{Column: 6, Level: "error", Line: 3, Message: "too many spaces before colon", Rule: "colons"},
+				{Column: 1, Level: "error", Line: 7, Message: "too many blank lines (3 > 2)", Rule: "empty-lines"},
+				{Column: 5, Level: "error", Line: 9, Message: "too many spaces after hyphen", Rule: "hyphens"},
+			},
+			source: "a:\n    b: 1\n    c : 2\nd: 3\n\n\n\nlist:\n  -  item\n",
+		},
+		"Comments": {
+			config: "rules:\n  comments: enable\n  comments-indentation: enable",
+			expected: []Problem{
+				{Column: 2, Level: "error", Line: 1, Message: "missing starting space in comment", Rule: "comments"},
+				{Column: 8, Level: "error", Line: 2, Message: "too few spaces before comment", Rule: "comments"},
+				{Column: 3, Level: "error", Line: 4, Message: "comment not indented like content", Rule: "comments-indentation"},
+			},
+			source: "#comment\nkey: a # comment\nlist:\n  # comment\n- item\n",
+		},
+		"Mapping keys": {
+			config: "rules:\n  key-duplicates: enable\n  key-ordering: enable",
+			expected: []Problem{
+				{Column: 1, Level: "error", Line: 2, Message: "wrong ordering of key \"a\" in mapping", Rule: "key-ordering"},
+				{Column: 1, Level: "error", Line: 3, Message: "duplication of key \"b\" in mapping", Rule: "key-duplicates"},
+			},
+			source: "b: 1\na: 2\nb: 3\n",
+		},
+		"Scalar values": {
+			config: "rules:\n  empty-values: enable\n  float-values: {forbid-nan: true}\n  octal-values: enable\n  quoted-strings: {required: only-when-needed}",
+			expected: []Problem{
+				{Column: 3, Level: "error", Line: 1, Message: "empty value in block mapping", Rule: "empty-values"},
+				{Column: 4, Level: "error", Line: 2, Message: "forbidden implicit octal value \"010\"", Rule: "octal-values"},
+				{Column: 4, Level: "error", Line: 3, Message: "forbidden not a number value \".nan\"", Rule: "float-values"},
+				{Column: 4, Level: "error", Line: 4, Message: "string value is redundantly quoted with single quotes", Rule: "quoted-strings"},
+			},
+			source: "a:\nb: 010\nc: .nan\nd: 'value'\ne: 'true'\n",
+		},
+		"Anchors": {
+			config: "rules:\n  anchors: {forbid-duplicated-anchors: true, forbid-unused-anchors: true}",
+			expected: []Problem{
+				{Column: 4, Level: "error", Line: 1, Message: "found unused anchor \"x\"", Rule: "anchors"},
+				{Column: 4, Level: "error", Line: 3, Message: "found duplicated anchor \"y\"", Rule: "anchors"},
+			},
+			source: "a: &x 1\nb: &y 2\nc: &y 3\nd: *y\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config, err := ParseConfig(test.config, repofs.CreateFromMap(nil))
+
+			assert.NoError(t, err)
+
+			actual := Lint("file.yml", []byte(test.source), config)
+
+			assert.Equal(t, test.expected, actual, "%s expected problems %v, got %v", name, test.expected, actual)
+		})
+	}
+}